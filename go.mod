@@ -5,7 +5,7 @@ go 1.15
 require (
 	github.com/Knetic/govaluate v3.0.0+incompatible
 	github.com/stretchr/testify v1.6.1
-	golang.org/x/exp v0.0.0-20201008143054-e3b2a7f2fdc7 // indirect
+	golang.org/x/exp v0.0.0-20201008143054-e3b2a7f2fdc7
 	golang.org/x/tools v0.0.0-20201105220310-78b158585360 // indirect
 	gonum.org/v1/gonum v0.8.1
 )
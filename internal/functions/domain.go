@@ -46,7 +46,7 @@ func (d *VarDomain) Normalize(v float64) float64 {
 	}
 
 	if v > d.Top {
-		return d.Bottom
+		return d.Top
 	}
 
 	return v
@@ -85,3 +85,8 @@ func NewMultipleFuncDomain(ds ...VarDomain) *MultipleFuncDomain {
 func (m *MultipleFuncDomain) VarDomain(varIndex int) VarDomain {
 	return m.ds[varIndex]
 }
+
+// VarDomains копия областей определения всех переменных.
+func (m *MultipleFuncDomain) VarDomains() []VarDomain {
+	return append([]VarDomain(nil), m.ds...)
+}
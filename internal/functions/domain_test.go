@@ -0,0 +1,26 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarDomain_Normalize(t *testing.T) {
+	d := NewVarDomain(-1, 2)
+
+	tests := []struct {
+		name string
+		v    float64
+		want float64
+	}{
+		{name: "Inside", v: 0.5, want: 0.5},
+		{name: "Below", v: -3, want: -1},
+		{name: "Above", v: 5, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, d.Normalize(tt.v))
+		})
+	}
+}
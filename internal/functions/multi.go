@@ -0,0 +1,99 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNoExpressions = errors.New("no expressions for multi-objective function")
+
+// MultiExpression векторная функция из нескольких выражений с общими переменными.
+//
+// Переменные упорядочены по первому появлению в выражениях, начиная с первого.
+type MultiExpression struct {
+	exps  []*Expression
+	names []string
+}
+
+func NewMultiExpression(expressions ...string) (*MultiExpression, error) {
+	if len(expressions) == 0 {
+		return nil, ErrNoExpressions
+	}
+
+	m := &MultiExpression{exps: make([]*Expression, len(expressions))}
+
+	for i, expression := range expressions {
+		e, err := NewExpression(expression)
+		if err != nil {
+			return nil, err
+		}
+
+		m.exps[i] = e
+
+		for _, name := range e.vars() {
+			if !m.hasVar(name) {
+				m.names = append(m.names, name)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func MustMultiExpression(expressions ...string) *MultiExpression {
+	m, err := NewMultiExpression(expressions...)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Funcs значения всех выражений в точке x, записываются в dst.
+func (m *MultiExpression) Funcs(dst, x []float64) {
+	if len(x) != m.Dimension() {
+		panic(fmt.Sprintf("dimension of the problem must be %d", m.Dimension()))
+	}
+
+	if len(dst) != m.Objectives() {
+		panic(fmt.Sprintf("number of the objectives must be %d", m.Objectives()))
+	}
+
+	vars := make(map[string]interface{}, len(m.names))
+	for i, name := range m.names {
+		vars[name] = x[i]
+	}
+
+	for i, e := range m.exps {
+		y, err := e.exp.Evaluate(vars)
+		if err != nil {
+			panic(err)
+		}
+
+		dst[i] = y.(float64)
+	}
+}
+
+func (m *MultiExpression) Dimension() int {
+	return len(m.names)
+}
+
+// Objectives количество выражений.
+func (m *MultiExpression) Objectives() int {
+	return len(m.exps)
+}
+
+// Vars имена переменных в порядке их появления.
+func (m *MultiExpression) Vars() []string {
+	return append([]string(nil), m.names...)
+}
+
+func (m *MultiExpression) hasVar(name string) bool {
+	for _, exists := range m.names {
+		if exists == name {
+			return true
+		}
+	}
+
+	return false
+}
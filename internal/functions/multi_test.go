@@ -0,0 +1,31 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiExpression_Funcs(t *testing.T) {
+	asserting := assert.New(t)
+
+	f, err := NewMultiExpression("y * 10", "x + y", "z")
+	asserting.NoError(err)
+	asserting.Equal([]string{"y", "x", "z"}, f.Vars())
+	asserting.Equal(3, f.Dimension())
+	asserting.Equal(3, f.Objectives())
+
+	got := make([]float64, f.Objectives())
+	f.Funcs(got, []float64{2, 1, 5})
+	asserting.Equal([]float64{20, 3, 5}, got)
+}
+
+func TestMultiExpression_Errors(t *testing.T) {
+	asserting := assert.New(t)
+
+	_, err := NewMultiExpression()
+	asserting.Equal(ErrNoExpressions, err)
+
+	_, err = NewMultiExpression("x + ")
+	asserting.Error(err)
+}
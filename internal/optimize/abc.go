@@ -0,0 +1,310 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultABCFoodSources = 20
+	DefaultABCGBestFactor = 1.5
+)
+
+var ErrABCVariant = errors.New("unknown bee colony variant")
+
+// ABCVariant формула поиска около источника пищи.
+type ABCVariant int
+
+const (
+	// ABCClassic v_j = x_j + phi*(x_j - x_kj), phi из [-1, 1] (Karaboga).
+	ABCClassic ABCVariant = iota
+	// ABCGBest v_j = x_j + phi*(x_j - x_kj) + psi*(gbest_j - x_j), psi из [0, GBestFactor] (Zhu, Kwong).
+	ABCGBest
+)
+
+// ABCConfig настройки пчелиной колонии.
+type ABCConfig struct {
+	FD          functions.FuncDomain
+	FoodSources int // количество источников пищи, столько же рабочих пчел и наблюдателей.
+	Limit       int // неудачных попыток улучшить источник до его замены разведчиком, 0 - FoodSources*размерность.
+	Variant     ABCVariant
+	GBestFactor float64 // наибольший вес притяжения к лучшей точке для ABCGBest.
+	Seed        uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultABCConfig() *ABCConfig {
+	return &ABCConfig{
+		FD:          DefaultHSFD,
+		FoodSources: DefaultABCFoodSources,
+		Variant:     ABCClassic,
+		GBestFactor: DefaultABCGBestFactor,
+	}
+}
+
+// DefaultGABCConfig настройки пчелиной колонии с притяжением к лучшей точке.
+func DefaultGABCConfig() *ABCConfig {
+	conf := DefaultABCConfig()
+	conf.Variant = ABCGBest
+
+	return conf
+}
+
+func (c *ABCConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.FoodSources < 2 { //nolint
+		return fmt.Errorf("%w: FoodSources %d", ErrConfigPopulationSize, c.FoodSources)
+	}
+
+	if c.Limit < 0 {
+		return fmt.Errorf("%w: Limit %d", ErrConfigNegative, c.Limit)
+	}
+
+	if !(c.GBestFactor >= 0) {
+		return fmt.Errorf("%w: GBestFactor %v", ErrConfigNegative, c.GBestFactor)
+	}
+
+	if c.Variant < ABCClassic || c.Variant > ABCGBest {
+		return fmt.Errorf("%w: %d", ErrABCVariant, c.Variant)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*ABC)(nil)
+
+// ABC метод искусственной пчелиной колонии (Artificial Bee Colony).
+//
+// Поколение - одна фаза: рабочие пчелы, наблюдатели или разведчик. Кандидаты фазы вычисляются
+// одновременно в optimize.Settings.Concurrent задачах, разведчик ищет новый источник случайно в FD.
+type ABC struct {
+	populationMethod
+	conf  *ABCConfig
+	state *abcState
+}
+
+// abcPhase фаза пчелиной колонии.
+type abcPhase int
+
+const (
+	abcInit     abcPhase = iota // вычисление начальных источников.
+	abcEmployed                 // рабочие пчелы ищут около своих источников.
+	abcOnlooker                 // наблюдатели выбирают источники по их качеству.
+)
+
+type abcState struct {
+	dim   int
+	rnd   *random.Generator
+	limit int
+	phase abcPhase
+
+	sources []*memoryComponent // источники пищи.
+	trials  []int              // неудачных попыток улучшить источник.
+
+	candidates [][]float64 // кандидаты фазы.
+	owners     []int       // источник каждого кандидата.
+	scout      int         // источник, замененный разведчиком, -1 - нет.
+}
+
+// NewABC создать экземпляр метода пчелиной колонии. Настройки conf, по умолчанию DefaultABCConfig, копируются.
+func NewABC(conf *ABCConfig) (*ABC, error) {
+	if conf == nil {
+		conf = DefaultABCConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ABC{conf: &c}, nil
+}
+
+func MustABC(conf *ABCConfig) *ABC {
+	a, err := NewABC(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+func (a *ABC) Init(dim, tasks int) int {
+	c := a.conf
+	s := &abcState{
+		dim:     dim,
+		rnd:     random.NewGenerator(c.Seed),
+		limit:   c.Limit,
+		sources: make([]*memoryComponent, c.FoodSources),
+		trials:  make([]int, c.FoodSources),
+		owners:  make([]int, c.FoodSources),
+		scout:   -1,
+	}
+
+	if s.limit == 0 {
+		s.limit = c.FoodSources * dim
+	}
+
+	s.candidates = make([][]float64, c.FoodSources)
+	for i := range s.candidates {
+		s.candidates[i] = make([]float64, dim)
+	}
+
+	a.state = s
+
+	return a.init(a, tasks)
+}
+
+func (a *ABC) start(x []float64) {
+	s := a.state
+
+	for i := range s.sources {
+		if i == 0 {
+			s.sources[i] = &memoryComponent{X: normalizePoint(x, a.conf.FD)}
+		} else {
+			s.sources[i] = &memoryComponent{X: randomPoint(s.rnd, s.dim, a.conf.FD)}
+		}
+
+		s.trials[i] = 0
+	}
+
+	s.phase = abcInit
+	s.scout = -1
+}
+
+func (a *ABC) ask() [][]float64 {
+	s := a.state
+
+	switch s.phase {
+	case abcInit:
+		xs := make([][]float64, len(s.sources))
+		for i, source := range s.sources {
+			xs[i] = source.X
+		}
+
+		return xs
+	case abcEmployed:
+		for i := range s.sources {
+			s.owners[i] = i
+		}
+	default:
+		a.chooseSources()
+	}
+
+	for i, owner := range s.owners {
+		a.forage(owner, s.candidates[i])
+	}
+
+	if s.scout >= 0 {
+		return append(s.candidates, s.sources[s.scout].X)
+	}
+
+	return s.candidates
+}
+
+func (a *ABC) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := a.state
+
+	if s.phase == abcInit {
+		for i, f := range fs {
+			s.sources[i].F = f
+		}
+
+		s.phase = abcEmployed
+
+		return optimize.NotTerminated, nil
+	}
+
+	// разведчик вычислялся вместе с рабочими пчелами последним.
+	if s.scout >= 0 {
+		s.sources[s.scout].F = fs[len(fs)-1]
+		s.scout = -1
+	}
+
+	for i, owner := range s.owners {
+		source := s.sources[owner]
+
+		if fs[i] < source.F {
+			copy(source.X, xs[i])
+			source.F = fs[i]
+			s.trials[owner] = 0
+		} else {
+			s.trials[owner]++
+		}
+	}
+
+	if s.phase == abcEmployed {
+		s.phase = abcOnlooker
+	} else {
+		s.phase = abcEmployed
+		a.sendScout()
+	}
+
+	return optimize.NotTerminated, nil
+}
+
+// forage кандидат около источника i.
+func (a *ABC) forage(i int, v []float64) {
+	s := a.state
+	x := s.sources[i].X
+
+	k := s.rnd.Intn(len(s.sources) - 1)
+	if k >= i {
+		k++
+	}
+
+	copy(v, x)
+
+	j := s.rnd.Intn(s.dim)
+	v[j] = x[j] + s.rnd.FloatInRange(-1, 1)*(x[j]-s.sources[k].X[j])
+
+	if a.conf.Variant == ABCGBest && a.best.X != nil {
+		v[j] += s.rnd.FloatInRange(0, a.conf.GBestFactor) * (a.best.X[j] - x[j])
+	}
+
+	d := a.conf.FD.VarDomain(j)
+	v[j] = d.Normalize(v[j])
+}
+
+// chooseSources выбор источников наблюдателями рулеткой по качеству источников.
+func (a *ABC) chooseSources() {
+	s := a.state
+	weights := make([]float64, len(s.sources))
+
+	for i, source := range s.sources {
+		if source.F >= 0 {
+			weights[i] = 1 / (1 + source.F)
+		} else {
+			weights[i] = 1 + math.Abs(source.F)
+		}
+	}
+
+	copy(s.owners, rouletteSelect(s.rnd, weights, len(s.owners)))
+}
+
+// sendScout замена случайной точкой источника, который дольше всех не удавалось улучшить сверх Limit.
+func (a *ABC) sendScout() {
+	s := a.state
+	worst := 0
+
+	for i, trials := range s.trials {
+		if trials > s.trials[worst] {
+			worst = i
+		}
+	}
+
+	if s.trials[worst] <= s.limit {
+		return
+	}
+
+	s.sources[worst].X = randomPoint(s.rnd, s.dim, a.conf.FD)
+	s.trials[worst] = 0
+	s.scout = worst
+}
@@ -0,0 +1,111 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestABC_Run(t *testing.T) {
+	variants := []struct {
+		name string
+		conf func() *internaloptimize.ABCConfig
+	}{
+		{name: "Classic", conf: internaloptimize.DefaultABCConfig},
+		{name: "GBest", conf: internaloptimize.DefaultGABCConfig},
+		{
+			name: "FrequentScouts",
+			conf: func() *internaloptimize.ABCConfig {
+				conf := internaloptimize.DefaultGABCConfig()
+				conf.Limit = 5
+
+				return conf
+			},
+		},
+	}
+	problems := []struct {
+		name    string
+		exp     string
+		minimum float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, minimum: 0},
+		{name: "Levi13", exp: functions.Levi13, minimum: 0},
+		{name: "Matias", exp: functions.Matias, minimum: 0},
+	}
+
+	for _, variant := range variants {
+		for _, problem := range problems {
+			t.Run(variant.name+"/"+problem.name, func(t *testing.T) {
+				asserting := assert.New(t)
+
+				d := functions.VarDomain{Bottom: -10, Top: 10}
+				conf := variant.conf()
+				conf.FD = functions.NewSingleFuncDomain(d)
+				conf.Seed = 3
+
+				prob := functions.MustProblem(problem.exp, nil, nil)
+				settings := &optimize.Settings{
+					Converger:       optimize.NeverTerminate{},
+					FuncEvaluations: 10_000,
+					Concurrent:      4,
+				}
+
+				result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustABC(conf))
+				asserting.NoError(err)
+				asserting.InDelta(problem.minimum, result.F, 1e-4)
+
+				for _, v := range result.X {
+					asserting.NoError(d.Validate(v))
+				}
+			})
+		}
+	}
+}
+
+func TestABCConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.ABCConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.ABCConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.ABCConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "SingleSource",
+			modify: func(c *internaloptimize.ABCConfig) { c.FoodSources = 1 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "NegativeLimit",
+			modify: func(c *internaloptimize.ABCConfig) { c.Limit = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name:   "UnknownVariant",
+			modify: func(c *internaloptimize.ABCConfig) { c.Variant = 10 },
+			err:    internaloptimize.ErrABCVariant,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultABCConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewABC(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
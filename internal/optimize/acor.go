@@ -0,0 +1,212 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultACORArchiveSize = 50
+	DefaultACORAnts        = 2
+	DefaultACORQ           = 1e-4
+	DefaultACORXi          = 0.85
+)
+
+var ErrACORQ = errors.New("kernel selection width q must be positive")
+
+// ACORConfig настройки муравьиной колонии для непрерывных областей.
+type ACORConfig struct {
+	FD          functions.FuncDomain
+	ArchiveSize int     // количество решений в архиве k.
+	Ants        int     // муравьев в поколении m, новых решений за поколение.
+	Q           float64 // ширина q весов рангов, чем меньше, тем чаще выбираются лучшие решения архива.
+	Xi          float64 // скорость сходимости xi, множитель стандартного отклонения ядра.
+	Seed        uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultACORConfig() *ACORConfig {
+	return &ACORConfig{
+		FD:          DefaultHSFD,
+		ArchiveSize: DefaultACORArchiveSize,
+		Ants:        DefaultACORAnts,
+		Q:           DefaultACORQ,
+		Xi:          DefaultACORXi,
+	}
+}
+
+func (c *ACORConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.ArchiveSize < 2 { //nolint
+		return fmt.Errorf("%w: ArchiveSize %d", ErrConfigPopulationSize, c.ArchiveSize)
+	}
+
+	if c.Ants < 1 {
+		return fmt.Errorf("%w: Ants %d", ErrConfigPopulationSize, c.Ants)
+	}
+
+	if !(c.Q > 0) {
+		return fmt.Errorf("%w: %v", ErrACORQ, c.Q)
+	}
+
+	if !(c.Xi >= 0) {
+		return fmt.Errorf("%w: Xi %v", ErrConfigNegative, c.Xi)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*ACOR)(nil)
+
+// ACOR муравьиная колония для непрерывных областей (Ant Colony Optimization for continuous domains, Socha, Dorigo).
+//
+// Архив лучших решений играет роль феромона, как память гармоний в HS. Муравей выбирает решение архива
+// с весом, убывающим по его рангу, и сэмплирует новую точку из нормального ядра с центром в нем.
+// Муравьи поколения вычисляются одновременно в optimize.Settings.Concurrent задачах.
+type ACOR struct {
+	populationMethod
+	conf  *ACORConfig
+	state *acorState
+}
+
+type acorState struct {
+	dim int
+	rnd *random.Generator
+
+	archive []*memoryComponent // решения, упорядоченные по возрастанию значения.
+	weights []float64          // веса рангов архива.
+	ants    [][]float64        // точки поколения.
+
+	evaluated bool // вычислен ли начальный архив.
+}
+
+// NewACOR создать экземпляр муравьиной колонии. Настройки conf, по умолчанию DefaultACORConfig, копируются.
+func NewACOR(conf *ACORConfig) (*ACOR, error) {
+	if conf == nil {
+		conf = DefaultACORConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ACOR{conf: &c}, nil
+}
+
+func MustACOR(conf *ACORConfig) *ACOR {
+	a, err := NewACOR(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+func (a *ACOR) Init(dim, tasks int) int {
+	c := a.conf
+	s := &acorState{
+		dim:     dim,
+		rnd:     random.NewGenerator(c.Seed),
+		archive: make([]*memoryComponent, c.ArchiveSize),
+		weights: make([]float64, c.ArchiveSize),
+		ants:    make([][]float64, c.Ants),
+	}
+
+	// w_l = exp(-(l-1)^2 / (2*q^2*k^2)) / (q*k*sqrt(2*pi)), ранг l от 1.
+	qk := c.Q * float64(c.ArchiveSize)
+	for l := range s.weights {
+		s.weights[l] = math.Exp(-float64(l*l)/(2*qk*qk)) / (qk * math.Sqrt(2*math.Pi)) //nolint
+	}
+
+	for i := range s.ants {
+		s.ants[i] = make([]float64, dim)
+	}
+
+	a.state = s
+
+	return a.init(a, tasks)
+}
+
+func (a *ACOR) start(x []float64) {
+	s := a.state
+
+	for i := range s.archive {
+		if i == 0 {
+			s.archive[i] = &memoryComponent{X: normalizePoint(x, a.conf.FD)}
+		} else {
+			s.archive[i] = &memoryComponent{X: randomPoint(s.rnd, s.dim, a.conf.FD)}
+		}
+	}
+
+	s.evaluated = false
+}
+
+func (a *ACOR) ask() [][]float64 {
+	s := a.state
+
+	if !s.evaluated {
+		xs := make([][]float64, len(s.archive))
+		for i, solution := range s.archive {
+			xs[i] = solution.X
+		}
+
+		return xs
+	}
+
+	for i, l := range rouletteSelect(s.rnd, s.weights, len(s.ants)) {
+		a.sample(l, s.ants[i])
+	}
+
+	return s.ants
+}
+
+func (a *ACOR) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := a.state
+
+	if !s.evaluated {
+		for i, f := range fs {
+			s.archive[i].F = f
+		}
+
+		s.evaluated = true
+	} else {
+		for i, f := range fs {
+			s.archive = append(s.archive, &memoryComponent{F: f, X: append([]float64(nil), xs[i]...)})
+		}
+	}
+
+	sort.SliceStable(s.archive, func(i, j int) bool {
+		return s.archive[i].F < s.archive[j].F
+	})
+	s.archive = s.archive[:a.conf.ArchiveSize]
+
+	return optimize.NotTerminated, nil
+}
+
+// sample точка из нормального ядра с центром в решении архива ранга l. Стандартное отклонение
+// по каждой переменной - Xi, умноженное на среднее расстояние от решения до остальных решений архива.
+func (a *ACOR) sample(l int, x []float64) {
+	s := a.state
+	center := s.archive[l].X
+
+	for j := range x {
+		distance := 0.0
+		for _, solution := range s.archive {
+			distance += math.Abs(solution.X[j] - center[j])
+		}
+
+		sigma := a.conf.Xi * distance / float64(len(s.archive)-1)
+
+		d := a.conf.FD.VarDomain(j)
+		x[j] = d.Normalize(center[j] + s.rnd.NormalStep(sigma))
+	}
+}
@@ -0,0 +1,98 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestACOR_Run(t *testing.T) {
+	problems := []struct {
+		name    string
+		exp     string
+		minimum float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, minimum: 0},
+		{name: "Levi13", exp: functions.Levi13, minimum: 0},
+		{name: "Matias", exp: functions.Matias, minimum: 0},
+	}
+
+	for _, problem := range problems {
+		t.Run(problem.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			d := functions.VarDomain{Bottom: -10, Top: 10}
+			conf := internaloptimize.DefaultACORConfig()
+			conf.FD = functions.NewSingleFuncDomain(d)
+			conf.Seed = 3
+
+			prob := functions.MustProblem(problem.exp, nil, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 10_000,
+				Concurrent:      4,
+			}
+
+			result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustACOR(conf))
+			asserting.NoError(err)
+			asserting.InDelta(problem.minimum, result.F, 1e-4)
+
+			for _, v := range result.X {
+				asserting.NoError(d.Validate(v))
+			}
+		})
+	}
+}
+
+func TestACORConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.ACORConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.ACORConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.ACORConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "SingleSolution",
+			modify: func(c *internaloptimize.ACORConfig) { c.ArchiveSize = 1 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "NoAnts",
+			modify: func(c *internaloptimize.ACORConfig) { c.Ants = 0 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "ZeroQ",
+			modify: func(c *internaloptimize.ACORConfig) { c.Q = 0 },
+			err:    internaloptimize.ErrACORQ,
+		},
+		{
+			name:   "NegativeXi",
+			modify: func(c *internaloptimize.ACORConfig) { c.Xi = -0.5 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultACORConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewACOR(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
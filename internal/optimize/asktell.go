@@ -0,0 +1,370 @@
+package optimize
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+var (
+	ErrAskTellMethod      = errors.New("method does not support ask/tell")
+	ErrAskTellLocalSearch = errors.New("harmony search local search needs optimize.Minimize")
+	ErrAskTellPoints      = errors.New("told points do not match asked points")
+	ErrAskTellTerminated  = errors.New("optimization is terminated")
+	ErrAskTellState       = errors.New("state does not match the method")
+	ErrAskTellSeed        = errors.New("random method needs a nonzero seed to restore its state")
+)
+
+// AskTellGeneration поколение точек и их значений.
+type AskTellGeneration struct {
+	X [][]float64
+	F []float64
+}
+
+// AskTellState состояние оптимизации ask/tell: стартовая точка и все вычисленные поколения.
+//
+// Метод восстанавливается повторением поколений без вычисления функции, поэтому он должен быть создан
+// с теми же настройками и ненулевым Seed.
+type AskTellState struct {
+	Dim     int
+	Tasks   int // точек, вычисляемых одновременно.
+	Start   []float64
+	History []AskTellGeneration
+}
+
+// Save запись состояния в формате format.
+func (s *AskTellState) Save(w io.Writer, format HSCheckpointFormat) error {
+	switch format {
+	case HSCheckpointJSON:
+		return json.NewEncoder(w).Encode(s)
+	case HSCheckpointGob:
+		return gob.NewEncoder(w).Encode(s)
+	default:
+		return fmt.Errorf("unknown checkpoint format %d", format)
+	}
+}
+
+// LoadAskTellState чтение состояния в формате format.
+func LoadAskTellState(r io.Reader, format HSCheckpointFormat) (*AskTellState, error) {
+	s := &AskTellState{}
+
+	var err error
+
+	switch format {
+	case HSCheckpointJSON:
+		err = json.NewDecoder(r).Decode(s)
+	case HSCheckpointGob:
+		err = gob.NewDecoder(r).Decode(s)
+	default:
+		err = fmt.Errorf("unknown checkpoint format %d", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// AskTell оптимизация с внешним вычислением функции, например измерением или задачей в другой системе:
+// Ask возвращает точки поколения, Tell сообщает их значения.
+//
+// Поддерживаются популяционные методы пакета и HS, GHS, SaHS без HSConfig.LocalSearch. Под optimize.Minimize
+// те же методы выполняют те же шаги, поэтому при одинаковых Seed и значениях функции траектории совпадают.
+type AskTell struct {
+	method optimize.Method
+	pop    populationMethod // лучшая точка, количество вычислений и статус, как в optimize.Minimize.
+	state  AskTellState
+	asked  [][]float64 // точки поколения, ожидающие значений.
+}
+
+// NewAskTell начать оптимизацию методом method из стартовой точки x, tasks точек вычисляются одновременно.
+// Метод, использующий случайные числа, должен быть создан с ненулевым Seed, иначе состояние нельзя
+// будет восстановить.
+func NewAskTell(method optimize.Method, x []float64, tasks int) (*AskTell, error) {
+	gen, err := askTellGenerational(method)
+	if err != nil {
+		return nil, err
+	}
+
+	if r, ok := method.(reproducible); ok && !r.reproducible() {
+		return nil, fmt.Errorf("%w: %T", ErrAskTellSeed, method)
+	}
+
+	a := &AskTell{method: method}
+	tasks = a.pop.init(gen, method.Init(len(x), tasks))
+	a.state = AskTellState{
+		Dim:   len(x),
+		Tasks: tasks,
+		Start: append([]float64(nil), x...),
+	}
+
+	gen.start(append([]float64(nil), x...))
+
+	return a, nil
+}
+
+// RestoreAskTell продолжить оптимизацию методом method с состояния state.
+func RestoreAskTell(method optimize.Method, state *AskTellState) (*AskTell, error) {
+	if len(state.Start) != state.Dim {
+		return nil, fmt.Errorf("%w: start dimension %d, expected %d", ErrAskTellState, len(state.Start), state.Dim)
+	}
+
+	a, err := NewAskTell(method, state.Start, state.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, g := range state.History {
+		xs := a.Ask()
+		if len(xs) != len(g.X) {
+			return nil, fmt.Errorf("%w: generation %d has %d points, expected %d", ErrAskTellState, i, len(g.X), len(xs))
+		}
+
+		for j := range xs {
+			if !floats.Equal(xs[j], g.X[j]) {
+				return nil, fmt.Errorf("%w: generation %d point %d differs", ErrAskTellState, i, j)
+			}
+		}
+
+		if _, err := a.Tell(g.X, g.F); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+// Ask точки очередного поколения. До Tell возвращает те же точки, после завершения - nil.
+// Поколение может быть пустым, тогда Tell сообщаются пустые значения.
+func (a *AskTell) Ask() [][]float64 {
+	if a.pop.status != optimize.NotTerminated || a.pop.err != nil {
+		return nil
+	}
+
+	if a.asked == nil {
+		a.asked = a.pop.gen.ask()
+	}
+
+	xs := make([][]float64, len(a.asked))
+	for i, x := range a.asked {
+		xs[i] = append([]float64(nil), x...)
+	}
+
+	return xs
+}
+
+// Tell значения fs точек xs последнего поколения Ask в том же порядке.
+// Вернет статус, отличный от optimize.NotTerminated, если метод завершил поиск.
+func (a *AskTell) Tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	if a.pop.status != optimize.NotTerminated || a.pop.err != nil {
+		return a.pop.status, ErrAskTellTerminated
+	}
+
+	if a.asked == nil {
+		a.asked = a.pop.gen.ask()
+	}
+
+	asked := a.asked
+
+	if len(xs) != len(asked) || len(fs) != len(asked) {
+		return optimize.NotTerminated, fmt.Errorf("%w: %d points and %d values for %d asked",
+			ErrAskTellPoints, len(xs), len(fs), len(asked))
+	}
+
+	for i := range xs {
+		if !floats.Equal(xs[i], asked[i]) {
+			return optimize.NotTerminated, fmt.Errorf("%w: point %d is %v, asked %v", ErrAskTellPoints, i, xs[i], asked[i])
+		}
+	}
+
+	generation := AskTellGeneration{X: make([][]float64, len(xs)), F: append([]float64(nil), fs...)}
+	for i, x := range xs {
+		generation.X[i] = append([]float64(nil), x...)
+	}
+
+	a.asked = nil
+	a.state.History = append(a.state.History, generation)
+	a.pop.status, a.pop.err = a.pop.told(asked, fs)
+
+	return a.pop.status, a.pop.err
+}
+
+// Result лучшая точка, количество вычислений, поколений и статус.
+func (a *AskTell) Result() *optimize.Result {
+	return &optimize.Result{
+		Location: optimize.Location{
+			X: append([]float64(nil), a.pop.best.X...),
+			F: a.pop.best.F,
+		},
+		Stats: optimize.Stats{
+			MajorIterations: a.pop.generations,
+			FuncEvaluations: a.pop.evaluations,
+		},
+		Status: a.pop.status,
+	}
+}
+
+// State копия состояния для продолжения оптимизации в RestoreAskTell.
+func (a *AskTell) State() *AskTellState {
+	s := a.state
+	s.Start = append([]float64(nil), s.Start...)
+	s.History = append([]AskTellGeneration(nil), s.History...)
+
+	return &s
+}
+
+// askTellGenerational метод в виде поколений.
+func askTellGenerational(method optimize.Method) (generational, error) {
+	switch m := method.(type) {
+	case harmonySearch:
+		h := m.harmony()
+		if h.conf.LocalSearch != nil {
+			return nil, ErrAskTellLocalSearch
+		}
+
+		return &hsGenerational{h: h}, nil
+	case generational:
+		return m, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrAskTellMethod, method)
+}
+
+// reproducible метод, траектория которого повторяется при тех же значениях функции:
+// зерно генератора задано или случайные числа не используются.
+type reproducible interface {
+	reproducible() bool
+}
+
+func (h *HS) reproducible() bool      { return h.conf.Seed != 0 }
+func (a *ABC) reproducible() bool     { return a.conf.Seed != 0 }
+func (a *ACOR) reproducible() bool    { return a.conf.Seed != 0 }
+func (b *BO) reproducible() bool      { return b.conf.Seed != 0 }
+func (c *Cuckoo) reproducible() bool  { return c.conf.Seed != 0 }
+func (d *DE) reproducible() bool      { return d.conf.Seed != 0 }
+func (f *Firefly) reproducible() bool { return f.conf.Seed != 0 }
+func (g *GA) reproducible() bool      { return g.conf.Seed != 0 }
+func (g *GWO) reproducible() bool     { return g.conf.Seed != 0 }
+func (p *PSO) reproducible() bool     { return p.conf.Seed != 0 }
+func (a *SA) reproducible() bool      { return a.conf.Seed != 0 }
+func (w *WOA) reproducible() bool     { return w.conf.Seed != 0 }
+
+func (p *Pattern) reproducible() bool {
+	return p.conf.Mode != PatternMADS || p.conf.Seed != 0
+}
+
+func (s *Search) reproducible() bool {
+	return s.conf.Mode != SearchRandom || s.conf.Seed != 0
+}
+
+// harmonySearch HS и методы на его основе.
+type harmonySearch interface {
+	optimize.Method
+	harmony() *HS
+}
+
+func (h *HS) harmony() *HS {
+	return h
+}
+
+// hsGenerational шаги гармонического поиска в виде поколений: первое заполняет память,
+// каждое следующее - одна импровизация.
+type hsGenerational struct {
+	h         *HS
+	harmonies []HSHarmony // гармонии для заполнения памяти, nil - память заполнена.
+	err       error       // ошибка, обнаруженная до начала поиска.
+}
+
+func (g *hsGenerational) start(x []float64) {
+	h := g.h
+	g.harmonies = nil
+	g.err = h.state.err
+
+	if g.err == nil && h.conf.Checkpointer != nil {
+		g.err = h.conf.Checkpointer.Init()
+	}
+
+	if g.err != nil || len(h.state.memory) != 0 { // ошибка или продолжение с контрольной точки.
+		return
+	}
+
+	seeds, err := h.seeds(HSHarmony{X: x})
+	if err != nil {
+		g.err = err
+
+		return
+	}
+
+	h.state.current = seeds[0].X
+	g.harmonies = h.memoryCandidates(seeds)
+}
+
+func (g *hsGenerational) ask() [][]float64 {
+	h := g.h
+
+	if g.err != nil {
+		return nil
+	}
+
+	if g.harmonies != nil {
+		var xs [][]float64
+
+		for _, harmony := range g.harmonies {
+			if !harmony.Evaluated {
+				xs = append(xs, harmony.X)
+			}
+		}
+
+		return xs
+	}
+
+	if h.state.pending == nil {
+		h.state.pending = h.improvisation(append([]float64(nil), h.state.current...))
+	}
+
+	return [][]float64{h.state.pending}
+}
+
+func (g *hsGenerational) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	h := g.h
+
+	if g.err != nil {
+		h.state.status = optimize.Failure
+		h.state.err = g.err
+
+		return optimize.Failure, g.err
+	}
+
+	h.state.evaluations += len(fs)
+
+	if g.harmonies != nil {
+		i := 0
+
+		for k := range g.harmonies {
+			if !g.harmonies[k].Evaluated {
+				g.harmonies[k].F = fs[i]
+				g.harmonies[k].Evaluated = true
+				i++
+			}
+		}
+
+		h.fillMemory(g.harmonies)
+		g.harmonies = nil
+
+		return optimize.NotTerminated, nil
+	}
+
+	h.state.pending = nil
+
+	if _, done := h.improvised(xs[0], fs[0]); done {
+		return h.state.status, h.state.err
+	}
+
+	return optimize.NotTerminated, nil
+}
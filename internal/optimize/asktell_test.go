@@ -0,0 +1,242 @@
+package optimize_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestAskTell_Minimize(t *testing.T) {
+	fd := functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+
+	hsOpts := []internaloptimize.HSOption{
+		internaloptimize.WithHSFD(fd),
+		internaloptimize.WithHSTermination(0, 500),
+		internaloptimize.WithHSSeed(3),
+	}
+
+	pattern := func(mode internaloptimize.PatternMode) func() optimize.Method {
+		return func() optimize.Method {
+			conf := internaloptimize.DefaultPatternConfig()
+			conf.FD = fd
+			conf.Mode = mode
+			conf.Seed = 3
+
+			return internaloptimize.MustPattern(conf)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		method func() optimize.Method
+	}{
+		{name: "HS", method: func() optimize.Method { return internaloptimize.MustHS(nil, hsOpts...) }},
+		{name: "GHS", method: func() optimize.Method { return internaloptimize.MustGHS(nil, hsOpts...) }},
+		{name: "SaHS", method: func() optimize.Method { return internaloptimize.MustSaHS(nil, hsOpts...) }},
+		{name: "Compass", method: pattern(internaloptimize.PatternCompass)},
+		{name: "MADS", method: pattern(internaloptimize.PatternMADS)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+			x := []float64{9, 9}
+
+			settings := &optimize.Settings{Converger: optimize.NeverTerminate{}}
+
+			expected, err := optimize.Minimize(prob, x, settings, test.method())
+			asserting.NoError(err)
+
+			at, err := internaloptimize.NewAskTell(test.method(), x, 1)
+			asserting.NoError(err)
+
+			status := optimize.NotTerminated
+			for status == optimize.NotTerminated {
+				xs := at.Ask()
+
+				fs := make([]float64, len(xs))
+				for i := range xs {
+					fs[i] = prob.Func(xs[i])
+				}
+
+				status, err = at.Tell(xs, fs)
+				asserting.NoError(err)
+			}
+
+			result := at.Result()
+			asserting.Equal(expected.Status, result.Status)
+			asserting.Equal(expected.X, result.X)
+			asserting.Equal(expected.F, result.F)
+			asserting.Equal(expected.FuncEvaluations, result.FuncEvaluations)
+			asserting.Nil(at.Ask())
+		})
+	}
+}
+
+func TestAskTell_State(t *testing.T) {
+	formats := []struct {
+		name   string
+		format internaloptimize.HSCheckpointFormat
+	}{
+		{name: "JSON", format: internaloptimize.HSCheckpointJSON},
+		{name: "Gob", format: internaloptimize.HSCheckpointGob},
+	}
+
+	for _, format := range formats {
+		t.Run(format.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			newHS := func() optimize.Method {
+				return internaloptimize.MustHS(nil, internaloptimize.WithHSSeed(3), internaloptimize.WithHSMemorySize(10))
+			}
+
+			prob := functions.MustProblem(functions.Matias, nil, nil)
+
+			at, err := internaloptimize.NewAskTell(newHS(), []float64{50, 50}, 1)
+			asserting.NoError(err)
+
+			for i := 0; i < 100; i++ {
+				xs := at.Ask()
+				fs := make([]float64, len(xs))
+
+				for j := range xs {
+					fs[j] = prob.Func(xs[j])
+				}
+
+				_, err = at.Tell(xs, fs)
+				asserting.NoError(err)
+			}
+
+			pending := at.Ask()
+
+			var buf bytes.Buffer
+			asserting.NoError(at.State().Save(&buf, format.format))
+
+			state, err := internaloptimize.LoadAskTellState(&buf, format.format)
+			asserting.NoError(err)
+
+			restored, err := internaloptimize.RestoreAskTell(newHS(), state)
+			asserting.NoError(err)
+			asserting.Equal(pending, restored.Ask())
+			asserting.Equal(at.Result(), restored.Result())
+
+			for i := 0; i < 100; i++ {
+				xs := at.Ask()
+				asserting.Equal(xs, restored.Ask())
+
+				fs := make([]float64, len(xs))
+				for j := range xs {
+					fs[j] = prob.Func(xs[j])
+				}
+
+				_, err = at.Tell(xs, fs)
+				asserting.NoError(err)
+				_, err = restored.Tell(xs, fs)
+				asserting.NoError(err)
+			}
+
+			asserting.Equal(at.Result(), restored.Result())
+
+			_, err = internaloptimize.RestoreAskTell(internaloptimize.MustHS(nil, internaloptimize.WithHSSeed(4),
+				internaloptimize.WithHSMemorySize(10)), state)
+			asserting.True(errors.Is(err, internaloptimize.ErrAskTellState), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestAskTell_TellBeforeAsk(t *testing.T) {
+	asserting := assert.New(t)
+
+	newDE := func() *internaloptimize.AskTell {
+		conf := internaloptimize.DefaultDEConfig()
+		conf.Seed = 3
+
+		at, err := internaloptimize.NewAskTell(internaloptimize.MustDE(conf), []float64{1, 1}, 1)
+		asserting.NoError(err)
+
+		return at
+	}
+
+	prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+	tell := func(at *internaloptimize.AskTell, xs [][]float64) {
+		fs := make([]float64, len(xs))
+		for i := range xs {
+			fs[i] = prob.Func(xs[i])
+		}
+
+		_, err := at.Tell(xs, fs)
+		asserting.NoError(err)
+	}
+
+	at, expected := newDE(), newDE()
+	tell(at, at.Ask())
+	tell(expected, expected.Ask())
+
+	// неверный Tell до Ask проверяется по точкам поколения, и Ask вернет это же поколение.
+	_, err := at.Tell([][]float64{{2, 2}}, []float64{0})
+	asserting.True(errors.Is(err, internaloptimize.ErrAskTellPoints), "unexpected error %v", err)
+	asserting.Equal(expected.Ask(), at.Ask())
+}
+
+func TestAskTell_Errors(t *testing.T) {
+	asserting := assert.New(t)
+
+	_, err := internaloptimize.NewAskTell(&optimize.NelderMead{}, []float64{1, 1}, 1)
+	asserting.True(errors.Is(err, internaloptimize.ErrAskTellMethod), "unexpected error %v", err)
+
+	local := internaloptimize.MustHS(nil, internaloptimize.WithHSLocalSearch(internaloptimize.DefaultHSLocalSearch()))
+	_, err = internaloptimize.NewAskTell(local, []float64{1, 1}, 1)
+	asserting.True(errors.Is(err, internaloptimize.ErrAskTellLocalSearch), "unexpected error %v", err)
+
+	hs := internaloptimize.MustHS(nil, internaloptimize.WithHSSeed(3))
+
+	at, err := internaloptimize.NewAskTell(hs, []float64{1, 1}, 1)
+	asserting.NoError(err)
+
+	xs := at.Ask()
+
+	_, err = at.Tell(xs[:1], []float64{0})
+	asserting.True(errors.Is(err, internaloptimize.ErrAskTellPoints), "unexpected error %v", err)
+
+	_, err = at.Tell([][]float64{{2, 2}}, []float64{0})
+	asserting.True(errors.Is(err, internaloptimize.ErrAskTellPoints), "unexpected error %v", err)
+
+	fs := make([]float64, len(xs))
+	fs[1] = math.NaN()
+
+	status, err := at.Tell(xs, fs)
+	asserting.Equal(optimize.Failure, status)
+	asserting.True(errors.Is(err, internaloptimize.ErrNaN), "unexpected error %v", err)
+	asserting.Nil(at.Ask())
+
+	_, err = at.Tell(xs, fs)
+	asserting.True(errors.Is(err, internaloptimize.ErrAskTellTerminated), "unexpected error %v", err)
+
+	_, err = internaloptimize.NewAskTell(internaloptimize.MustHS(nil), []float64{1, 1}, 1)
+	asserting.True(errors.Is(err, internaloptimize.ErrAskTellSeed), "unexpected error %v", err)
+
+	_, err = internaloptimize.NewAskTell(internaloptimize.MustDE(nil), []float64{1, 1}, 1)
+	asserting.True(errors.Is(err, internaloptimize.ErrAskTellSeed), "unexpected error %v", err)
+
+	compass := internaloptimize.DefaultPatternConfig()
+	compass.Mode = internaloptimize.PatternCompass
+	_, err = internaloptimize.NewAskTell(internaloptimize.MustPattern(compass), []float64{1, 1}, 1)
+	asserting.NoError(err, "deterministic method does not need a seed")
+
+	seed := internaloptimize.HSHarmony{X: []float64{1}}
+	seeded := internaloptimize.MustHS(nil, internaloptimize.WithHSInitialMemory(seed), internaloptimize.WithHSSeed(3))
+	at, err = internaloptimize.NewAskTell(seeded, []float64{1, 1}, 1)
+	asserting.NoError(err)
+
+	status, err = at.Tell(at.Ask(), nil)
+	asserting.Equal(optimize.Failure, status)
+	asserting.True(errors.Is(err, internaloptimize.ErrHSSeedDimension), "unexpected error %v", err)
+}
@@ -0,0 +1,202 @@
+package optimize_test
+
+import (
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// BenchmarkMethods сравнение методов на одних задачах при одинаковом количестве вычислений функции.
+// Метрика best-f - среднее лучшее значение.
+func BenchmarkMethods(b *testing.B) {
+	fd := functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+
+	methods := []struct {
+		name      string
+		newMethod func() optimize.Method
+	}{
+		{
+			name: "HS",
+			newMethod: func() optimize.Method {
+				return internaloptimize.MustHS(nil, internaloptimize.WithHSFD(fd))
+			},
+		},
+		{
+			name: "GHS",
+			newMethod: func() optimize.Method {
+				return internaloptimize.MustGHS(nil, internaloptimize.WithHSFD(fd))
+			},
+		},
+		{
+			name: "SaHS",
+			newMethod: func() optimize.Method {
+				return internaloptimize.MustSaHS(nil, internaloptimize.WithHSFD(fd))
+			},
+		},
+		{
+			name: "PSO",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultPSOConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustPSO(conf)
+			},
+		},
+		{
+			name: "DE",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultDEConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustDE(conf)
+			},
+		},
+		{
+			name: "SHADE",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultDEConfig()
+				conf.FD = fd
+				conf.Adaptation = internaloptimize.DESHADE
+
+				return internaloptimize.MustDE(conf)
+			},
+		},
+		{
+			name: "GA",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultGAConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustGA(conf)
+			},
+		},
+		{
+			name: "SA",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultSAConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustSA(conf)
+			},
+		},
+		{
+			name: "ABC",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultGABCConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustABC(conf)
+			},
+		},
+		{
+			name: "Firefly",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultFireflyConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustFirefly(conf)
+			},
+		},
+		{
+			name: "Cuckoo",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultCuckooConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustCuckoo(conf)
+			},
+		},
+		{
+			name: "GWO",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultGWOConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustGWO(conf)
+			},
+		},
+		{
+			name: "WOA",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultWOAConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustWOA(conf)
+			},
+		},
+		{
+			name: "ACOR",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultACORConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustACOR(conf)
+			},
+		},
+		{
+			name: "Random",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultSearchConfig()
+				conf.FD = fd
+				conf.Mode = internaloptimize.SearchRandom
+
+				return internaloptimize.MustSearch(conf)
+			},
+		},
+		{
+			name: "Sobol",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultSearchConfig()
+				conf.FD = fd
+				conf.Mode = internaloptimize.SearchSobol
+
+				return internaloptimize.MustSearch(conf)
+			},
+		},
+		{
+			name: "HookeJeeves",
+			newMethod: func() optimize.Method {
+				conf := internaloptimize.DefaultPatternConfig()
+				conf.FD = fd
+
+				return internaloptimize.MustPattern(conf)
+			},
+		},
+	}
+	problems := []struct {
+		name string
+		exp  string
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau},
+		{name: "Levi13", exp: functions.Levi13},
+		{name: "Matias", exp: functions.Matias},
+	}
+
+	for _, problem := range problems {
+		prob := functions.MustProblem(problem.exp, nil, nil)
+
+		for _, method := range methods {
+			b.Run(problem.name+"/"+method.name, func(b *testing.B) {
+				sum := 0.0
+
+				for i := 0; i < b.N; i++ {
+					settings := &optimize.Settings{
+						Converger:       optimize.NeverTerminate{},
+						FuncEvaluations: 5000,
+					}
+
+					result, err := optimize.Minimize(prob, []float64{9, 9}, settings, method.newMethod())
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					sum += result.F
+				}
+
+				b.ReportMetric(sum/float64(b.N), "best-f")
+			})
+		}
+	}
+}
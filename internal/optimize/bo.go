@@ -0,0 +1,306 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+const (
+	DefaultBOInitialSamples         = 10
+	DefaultBOExploration            = 0.01
+	DefaultBOKappa                  = 2
+	DefaultBOAcquisitionEvaluations = 2000
+)
+
+var ErrBOAcquisition = errors.New("unknown acquisition function")
+
+// BOAcquisition функция выбора следующей точки по предсказанию гауссовского процесса.
+type BOAcquisition int
+
+const (
+	// BOExpectedImprovement ожидаемое улучшение лучшего значения.
+	BOExpectedImprovement BOAcquisition = iota
+	// BOUpperConfidenceBound нижняя доверительная граница mean - Kappa*std для минимизации.
+	BOUpperConfidenceBound
+	// BOProbabilityOfImprovement вероятность улучшения лучшего значения.
+	BOProbabilityOfImprovement
+)
+
+// BOConfig настройки байесовской оптимизации.
+type BOConfig struct {
+	FD                     functions.FuncDomain
+	Kernel                 GPKernel
+	Acquisition            BOAcquisition
+	InitialSamples         int     // случайных точек до первого построения процесса, первая - стартовая точка.
+	Exploration            float64 // запас улучшения xi для EI и PI в единицах значений функции.
+	Kappa                  float64 // вес стандартного отклонения для UCB.
+	Batch                  int     // точек в поколении, 0 - optimize.Settings.Concurrent.
+	AcquisitionEvaluations int     // вычислений функции выбора методом HS для каждой точки.
+	Seed                   uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultBOConfig() *BOConfig {
+	return &BOConfig{
+		FD:                     DefaultHSFD,
+		Kernel:                 GPMatern52,
+		Acquisition:            BOExpectedImprovement,
+		InitialSamples:         DefaultBOInitialSamples,
+		Exploration:            DefaultBOExploration,
+		Kappa:                  DefaultBOKappa,
+		AcquisitionEvaluations: DefaultBOAcquisitionEvaluations,
+	}
+}
+
+func (c *BOConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.Kernel < GPMatern52 || c.Kernel > GPRBF {
+		return fmt.Errorf("%w: %d", ErrGPKernel, c.Kernel)
+	}
+
+	if c.Acquisition < BOExpectedImprovement || c.Acquisition > BOProbabilityOfImprovement {
+		return fmt.Errorf("%w: %d", ErrBOAcquisition, c.Acquisition)
+	}
+
+	if c.InitialSamples < 2 { //nolint
+		return fmt.Errorf("%w: InitialSamples %d", ErrConfigPopulationSize, c.InitialSamples)
+	}
+
+	if c.AcquisitionEvaluations < 1 {
+		return fmt.Errorf("%w: AcquisitionEvaluations %d", ErrConfigPopulationSize, c.AcquisitionEvaluations)
+	}
+
+	if c.Batch < 0 {
+		return fmt.Errorf("%w: Batch %d", ErrConfigNegative, c.Batch)
+	}
+
+	nonNegative := []struct {
+		name string
+		v    float64
+	}{
+		{name: "Exploration", v: c.Exploration},
+		{name: "Kappa", v: c.Kappa},
+	}
+
+	for _, v := range nonNegative {
+		if !(v.v >= 0) {
+			return fmt.Errorf("%w: %s %v", ErrConfigNegative, v.name, v.v)
+		}
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*BO)(nil)
+
+// BO байесовская оптимизация с гауссовским процессом в роли суррогатной модели.
+//
+// Процесс строится в FD, приведенной к единичному кубу, с гиперпараметрами по максимуму правдоподобия.
+// Следующая точка - максимум функции выбора, найденный методом HS. Точки поколения выбираются по очереди,
+// предсказания процесса в уже выбранных точках принимаются за наблюдения (kriging believer),
+// и поколение вычисляется одновременно в optimize.Settings.Concurrent задачах.
+type BO struct {
+	populationMethod
+	conf  *BOConfig
+	state *boState
+}
+
+type boState struct {
+	dim   int
+	rnd   *random.Generator
+	batch int
+	unit  functions.FuncDomain
+
+	x0 []float64 // стартовая точка.
+	gp *GP
+	us [][]float64 // наблюдения в единичном кубе.
+	fs []float64   // значения наблюдений.
+}
+
+// NewBO создать экземпляр байесовской оптимизации. Настройки conf, по умолчанию DefaultBOConfig, копируются.
+func NewBO(conf *BOConfig) (*BO, error) {
+	if conf == nil {
+		conf = DefaultBOConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &BO{conf: &c}, nil
+}
+
+func MustBO(conf *BOConfig) *BO {
+	b, err := NewBO(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (b *BO) Init(dim, tasks int) int {
+	tasks = b.init(b, tasks)
+
+	gp, _ := NewGP(b.conf.Kernel)
+	b.state = &boState{
+		dim:   dim,
+		rnd:   random.NewGenerator(b.conf.Seed),
+		batch: b.conf.Batch,
+		unit:  functions.NewSingleFuncDomain(functions.VarDomain{Bottom: 0, Top: 1}),
+		gp:    gp,
+	}
+
+	if b.state.batch == 0 {
+		b.state.batch = tasks
+	}
+
+	return tasks
+}
+
+func (b *BO) start(x []float64) {
+	s := b.state
+	s.x0 = normalizePoint(x, b.conf.FD)
+	s.us = s.us[:0]
+	s.fs = s.fs[:0]
+}
+
+func (b *BO) ask() [][]float64 {
+	s := b.state
+
+	if len(s.us) == 0 {
+		xs := make([][]float64, b.conf.InitialSamples)
+		for i := range xs {
+			if i == 0 {
+				xs[i] = s.x0
+			} else {
+				xs[i] = randomPoint(s.rnd, s.dim, b.conf.FD)
+			}
+		}
+
+		return xs
+	}
+
+	us := append([][]float64(nil), s.us...)
+	fs := append([]float64(nil), s.fs...)
+	xs := make([][]float64, 0, s.batch)
+
+	for len(xs) < s.batch {
+		u := b.propose()
+		mean, _ := s.gp.Predict(u)
+		xs = append(xs, b.fromUnit(u))
+
+		// kriging believer: следующая точка поколения выбирается так, будто в u наблюдалось предсказание.
+		us = append(us, u)
+		fs = append(fs, mean)
+
+		if len(xs) < s.batch && s.gp.Fit(us, fs) != nil {
+			break
+		}
+	}
+
+	return xs
+}
+
+func (b *BO) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := b.state
+
+	for i, x := range xs {
+		s.us = append(s.us, b.toUnit(x))
+		s.fs = append(s.fs, fs[i])
+	}
+
+	if err := s.gp.FitHyperparameters(s.us, s.fs); err != nil {
+		return optimize.Failure, err
+	}
+
+	return optimize.NotTerminated, nil
+}
+
+// propose точка единичного куба с наибольшим значением функции выбора.
+func (b *BO) propose() []float64 {
+	s := b.state
+
+	best := 0
+	for i, f := range s.fs {
+		if f < s.fs[best] {
+			best = i
+		}
+	}
+
+	fBest := s.fs[best]
+	xi := b.conf.Exploration
+	unit := distuv.UnitNormal
+
+	prob := optimize.Problem{
+		Func: func(u []float64) float64 {
+			mean, std := s.gp.Predict(u)
+
+			switch b.conf.Acquisition {
+			case BOUpperConfidenceBound:
+				return mean - b.conf.Kappa*std
+			case BOProbabilityOfImprovement:
+				if std == 0 {
+					return 0
+				}
+
+				return -unit.CDF((fBest - mean - xi) / std)
+			default:
+				if std == 0 {
+					return -math.Max(0, fBest-mean-xi)
+				}
+
+				z := (fBest - mean - xi) / std
+
+				return -((fBest-mean-xi)*unit.CDF(z) + std*unit.Prob(z))
+			}
+		},
+	}
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: b.conf.AcquisitionEvaluations,
+	}
+	hs := MustHS(nil, WithHSFD(s.unit), WithHSSeed(s.rnd.Uint64()))
+
+	result, err := optimize.Minimize(prob, s.us[best], settings, hs)
+	if err != nil || result == nil {
+		return randomPoint(s.rnd, s.dim, s.unit)
+	}
+
+	return result.X
+}
+
+// toUnit точка x FD в единичном кубе.
+func (b *BO) toUnit(x []float64) []float64 {
+	u := make([]float64, len(x))
+
+	for j, v := range x {
+		d := b.conf.FD.VarDomain(j)
+		if width := d.Top - d.Bottom; width > 0 {
+			u[j] = (v - d.Bottom) / width
+		}
+	}
+
+	return u
+}
+
+// fromUnit точка FD из точки u единичного куба.
+func (b *BO) fromUnit(u []float64) []float64 {
+	x := make([]float64, len(u))
+
+	for j, v := range u {
+		d := b.conf.FD.VarDomain(j)
+		x[j] = d.Normalize(d.Bottom + v*(d.Top-d.Bottom))
+	}
+
+	return x
+}
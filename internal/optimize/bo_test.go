@@ -0,0 +1,131 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestBO_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		kernel      internaloptimize.GPKernel
+		acquisition internaloptimize.BOAcquisition
+		batch       int
+	}{
+		{name: "EI/Matern52", kernel: internaloptimize.GPMatern52, acquisition: internaloptimize.BOExpectedImprovement},
+		{name: "EI/Matern32", kernel: internaloptimize.GPMatern32, acquisition: internaloptimize.BOExpectedImprovement},
+		{name: "EI/RBF", kernel: internaloptimize.GPRBF, acquisition: internaloptimize.BOExpectedImprovement},
+		{name: "UCB/Matern52", kernel: internaloptimize.GPMatern52, acquisition: internaloptimize.BOUpperConfidenceBound},
+		{
+			name:        "PI/Matern52",
+			kernel:      internaloptimize.GPMatern52,
+			acquisition: internaloptimize.BOProbabilityOfImprovement,
+		},
+		{
+			name:        "EI/Matern52/Batch",
+			kernel:      internaloptimize.GPMatern52,
+			acquisition: internaloptimize.BOExpectedImprovement,
+			batch:       6,
+		},
+	}
+	problems := []struct {
+		name    string
+		exp     string
+		minimum float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, minimum: 0},
+		{name: "Matias", exp: functions.Matias, minimum: 0},
+	}
+
+	for _, test := range tests {
+		for _, problem := range problems {
+			t.Run(test.name+"/"+problem.name, func(t *testing.T) {
+				asserting := assert.New(t)
+
+				d := functions.VarDomain{Bottom: -5, Top: 5}
+				conf := internaloptimize.DefaultBOConfig()
+				conf.FD = functions.NewSingleFuncDomain(d)
+				conf.Kernel = test.kernel
+				conf.Acquisition = test.acquisition
+				conf.Batch = test.batch
+				conf.AcquisitionEvaluations = 1000
+				conf.Seed = 3
+
+				prob := functions.MustProblem(problem.exp, nil, nil)
+				settings := &optimize.Settings{
+					Converger:       optimize.NeverTerminate{},
+					FuncEvaluations: 80,
+					Concurrent:      4,
+				}
+
+				// байесовская оптимизация нужна для дорогих функций, поэтому вычислений мало.
+				result, err := optimize.Minimize(prob, []float64{4, 4}, settings, internaloptimize.MustBO(conf))
+				asserting.NoError(err)
+				asserting.InDelta(problem.minimum, result.F, 1e-2)
+
+				for _, v := range result.X {
+					asserting.NoError(d.Validate(v))
+				}
+			})
+		}
+	}
+}
+
+func TestBOConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.BOConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.BOConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.BOConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "UnknownKernel",
+			modify: func(c *internaloptimize.BOConfig) { c.Kernel = 10 },
+			err:    internaloptimize.ErrGPKernel,
+		},
+		{
+			name:   "UnknownAcquisition",
+			modify: func(c *internaloptimize.BOConfig) { c.Acquisition = 10 },
+			err:    internaloptimize.ErrBOAcquisition,
+		},
+		{
+			name:   "SingleInitialSample",
+			modify: func(c *internaloptimize.BOConfig) { c.InitialSamples = 1 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "NegativeBatch",
+			modify: func(c *internaloptimize.BOConfig) { c.Batch = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name:   "NegativeKappa",
+			modify: func(c *internaloptimize.BOConfig) { c.Kappa = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultBOConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewBO(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
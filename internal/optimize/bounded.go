@@ -0,0 +1,426 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultBoundedPenalty = 1e3
+
+	// boundedInteriorTolerance насколько стартовая точка отодвигается от границы перед обратным
+	// преобразованием, в доле ширины области определения.
+	boundedInteriorTolerance = 1e-6
+)
+
+var (
+	ErrBoundedMethod  = errors.New("bounded method is not set")
+	ErrBoundedMode    = errors.New("unknown bound handling mode")
+	ErrBoundedPenalty = errors.New("penalty must be positive")
+)
+
+// BoundedMode способ удержания точек вложенного метода в области определения.
+type BoundedMode int
+
+const (
+	// BoundedSin замена переменной x = a + (b - a)*(sin(y) + 1)/2.
+	BoundedSin BoundedMode = iota
+	// BoundedLogistic замена переменной x = a + (b - a)/(1 + exp(-y)).
+	BoundedLogistic
+	// BoundedProjection функция вычисляется в проекции точки на область определения,
+	// по отсеченным переменным производные нулевые.
+	BoundedProjection
+	// BoundedPenalty как BoundedProjection, но к значению прибавляется Penalty*|y - P(y)|^2,
+	// что возвращает вложенный метод в область определения. На границе у функции излом, поэтому методы
+	// с линейным поиском могут завершиться у минимума на границе ошибкой линейного поиска.
+	BoundedPenalty
+)
+
+// BoundedConfig настройки ограничения метода областью определения.
+type BoundedConfig struct {
+	FD      functions.FuncDomain
+	Mode    BoundedMode
+	Penalty float64 // вес штрафа для BoundedPenalty.
+}
+
+func DefaultBoundedConfig() *BoundedConfig {
+	return &BoundedConfig{
+		FD:      DefaultHSFD,
+		Mode:    BoundedSin,
+		Penalty: DefaultBoundedPenalty,
+	}
+}
+
+func (c *BoundedConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.Mode < BoundedSin || c.Mode > BoundedPenalty {
+		return fmt.Errorf("%w: %d", ErrBoundedMode, c.Mode)
+	}
+
+	if c.Mode == BoundedPenalty && !(c.Penalty > 0) {
+		return fmt.Errorf("%w: %v", ErrBoundedPenalty, c.Penalty)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*Bounded)(nil)
+
+// Bounded обертка, которая ограничивает любой метод gonum областью определения функции.
+//
+// Вложенный метод ищет в пространстве y, функция вычисляется только в точках x = T(y) из FD.
+// Значение, градиент и гессиан пересчитываются в пространство y по правилу дифференцирования
+// сложной функции, поэтому методы второго порядка работают без изменений.
+type Bounded struct {
+	method optimize.Method
+	conf   *BoundedConfig
+
+	dim     int
+	mu      sync.Mutex
+	pending map[int]optimize.Task // задачи вложенного метода по Task.ID.
+	cache   map[int]*boundedCache // последние вычисления в пространстве x по Task.ID.
+}
+
+// boundedCache вычисленные значение и градиент в пространстве x для точки y.
+type boundedCache struct {
+	y        []float64
+	f        float64
+	hasF     bool
+	gradient []float64
+}
+
+// NewBounded ограничить метод method областью определения. Настройки conf, по умолчанию DefaultBoundedConfig,
+// копируются.
+func NewBounded(method optimize.Method, conf *BoundedConfig) (*Bounded, error) {
+	if method == nil {
+		return nil, ErrBoundedMethod
+	}
+
+	if conf == nil {
+		conf = DefaultBoundedConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Bounded{method: method, conf: &c}, nil
+}
+
+func MustBounded(method optimize.Method, conf *BoundedConfig) *Bounded {
+	b, err := NewBounded(method, conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// Method вложенный метод.
+func (b *Bounded) Method() optimize.Method {
+	return b.method
+}
+
+func (b *Bounded) Init(dim, tasks int) int {
+	tasks = b.method.Init(dim, tasks)
+
+	b.dim = dim
+	b.pending = make(map[int]optimize.Task, tasks)
+	b.cache = make(map[int]*boundedCache, tasks)
+
+	return tasks
+}
+
+func (b *Bounded) Uses(has optimize.Available) (optimize.Available, error) {
+	return b.method.Uses(has)
+}
+
+func (b *Bounded) Status() (optimize.Status, error) {
+	if s, ok := b.method.(optimize.Statuser); ok {
+		return s.Status()
+	}
+
+	return optimize.NotTerminated, nil
+}
+
+func (b *Bounded) Run(operation chan<- optimize.Task, result <-chan optimize.Task, tasks []optimize.Task) {
+	innerOperation := make(chan optimize.Task, len(tasks))
+	innerResult := make(chan optimize.Task, len(tasks))
+
+	// стартовая точка переводится в пространство y и вычисляется вложенным методом заново.
+	innerTasks := make([]optimize.Task, len(tasks))
+	for i, task := range tasks {
+		y := make([]float64, b.dim)
+		b.toY(y, task.X)
+
+		innerTasks[i] = optimize.Task{ID: task.ID, Op: optimize.NoOperation, Location: &optimize.Location{X: y}}
+	}
+
+	go b.method.Run(innerOperation, innerResult, innerTasks)
+
+	go func() {
+		defer close(innerResult)
+
+		for r := range result {
+			innerResult <- b.fromOuter(r)
+		}
+	}()
+
+	for task := range innerOperation {
+		operation <- b.toOuter(task)
+	}
+
+	close(operation)
+}
+
+// toOuter задача вложенного метода в пространстве x.
+func (b *Bounded) toOuter(task optimize.Task) optimize.Task {
+	var x *optimize.Location
+
+	op := task.Op
+
+	switch op {
+	case optimize.MajorIteration, optimize.MethodDone, optimize.NoOperation:
+		x = b.outerLocation(task.ID, task.Location)
+	default:
+		x = &optimize.Location{X: make([]float64, b.dim)}
+		b.toX(x.X, task.X)
+
+		// гессиан в пространстве y зависит и от градиента в точке x.
+		if op&optimize.HessEvaluation != 0 && op&optimize.GradEvaluation == 0 {
+			if x.Gradient = b.cachedGradient(task.ID, task.X); x.Gradient == nil {
+				op |= optimize.GradEvaluation
+			}
+		}
+	}
+
+	b.mu.Lock()
+	b.pending[task.ID] = task
+	b.mu.Unlock()
+
+	return optimize.Task{ID: task.ID, Op: op, Location: x}
+}
+
+// fromOuter ответ на задачу вложенного метода в пространстве y.
+func (b *Bounded) fromOuter(r optimize.Task) optimize.Task {
+	if r.Op == optimize.PostIteration {
+		return r
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	inner := b.pending[r.ID]
+
+	if r.Op&(optimize.FuncEvaluation|optimize.GradEvaluation|optimize.HessEvaluation) != 0 {
+		b.transform(r.Op, inner.Location, r.Location)
+		b.remember(r.ID, r.Op, inner.X, r.Location)
+	}
+
+	return inner
+}
+
+// cachedGradient градиент в пространстве x, уже вычисленный в точке y.
+func (b *Bounded) cachedGradient(id int, y []float64) []float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.cache[id]
+	if c == nil || c.gradient == nil || !floats.Equal(c.y, y) {
+		return nil
+	}
+
+	return append([]float64(nil), c.gradient...)
+}
+
+// outerLocation точка вложенного метода в пространстве x. Значение и градиент берутся из последнего
+// вычисления в этой точке, если оно было, иначе значение очищается от штрафа.
+func (b *Bounded) outerLocation(id int, y *optimize.Location) *optimize.Location {
+	x := &optimize.Location{X: make([]float64, b.dim)}
+	b.toX(x.X, y.X)
+	x.F = y.F - b.penalty(y.X, x.X)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.cache[id]
+	if c == nil || !floats.Equal(c.y, y.X) {
+		return x
+	}
+
+	if c.hasF {
+		x.F = c.f
+	}
+
+	if c.gradient != nil {
+		x.Gradient = append([]float64(nil), c.gradient...)
+	}
+
+	return x
+}
+
+// remember сохранение вычисленных в точке y значений.
+func (b *Bounded) remember(id int, op optimize.Operation, y []float64, x *optimize.Location) {
+	c := b.cache[id]
+
+	if c == nil || !floats.Equal(c.y, y) {
+		c = &boundedCache{y: append([]float64(nil), y...)}
+		b.cache[id] = c
+	}
+
+	if op&optimize.FuncEvaluation != 0 {
+		c.f = x.F
+		c.hasF = true
+	}
+
+	if op&optimize.GradEvaluation != 0 {
+		c.gradient = append(c.gradient[:0], x.Gradient...)
+	}
+}
+
+// transform пересчет вычисленных в точке x значений в точку y.
+func (b *Bounded) transform(op optimize.Operation, y, x *optimize.Location) {
+	dx := make([]float64, b.dim)
+	d2x := make([]float64, b.dim)
+	out := make([]float64, b.dim) // y - x по переменным, отсеченным проекцией.
+
+	for j := range dx {
+		dx[j], d2x[j] = b.derivatives(j, y.X[j])
+		out[j] = y.X[j] - x.X[j]
+	}
+
+	penalty := 0.0
+	if b.conf.Mode == BoundedPenalty {
+		penalty = b.conf.Penalty
+	}
+
+	if op&optimize.FuncEvaluation != 0 {
+		y.F = x.F + b.penalty(y.X, x.X)
+	}
+
+	if op&optimize.GradEvaluation != 0 {
+		if len(y.Gradient) != b.dim {
+			y.Gradient = make([]float64, b.dim)
+		}
+
+		for j := range y.Gradient {
+			y.Gradient[j] = x.Gradient[j]*dx[j] + 2*penalty*out[j] //nolint
+		}
+	}
+
+	if op&optimize.HessEvaluation != 0 {
+		if y.Hessian == nil {
+			y.Hessian = mat.NewSymDense(b.dim, nil)
+		}
+
+		for i := 0; i < b.dim; i++ {
+			for j := i; j < b.dim; j++ {
+				h := dx[i] * x.Hessian.At(i, j) * dx[j]
+
+				if i == j {
+					// без градиента в точке x слагаемое со второй производной замены неизвестно.
+					if x.Gradient != nil {
+						h += x.Gradient[i] * d2x[i]
+					}
+
+					if out[i] != 0 {
+						h += 2 * penalty //nolint
+					}
+				}
+
+				y.Hessian.SetSym(i, j, h)
+			}
+		}
+	}
+}
+
+// penalty штраф точки y, которой соответствует точка x.
+func (b *Bounded) penalty(y, x []float64) float64 {
+	if b.conf.Mode != BoundedPenalty {
+		return 0
+	}
+
+	d := floats.Distance(y, x, 2) //nolint
+
+	return b.conf.Penalty * d * d
+}
+
+// clamped переменная не преобразуется, а проецируется на область определения.
+func (b *Bounded) clamped(d functions.VarDomain) bool {
+	mode := b.conf.Mode
+	if mode == BoundedProjection || mode == BoundedPenalty {
+		return true
+	}
+
+	return math.IsInf(d.Bottom, 0) || math.IsInf(d.Top, 0) || d.Top <= d.Bottom
+}
+
+// toX точка x = T(y).
+func (b *Bounded) toX(x, y []float64) {
+	for j, v := range y {
+		d := b.conf.FD.VarDomain(j)
+
+		switch {
+		case b.clamped(d):
+			x[j] = d.Normalize(v)
+		case b.conf.Mode == BoundedSin:
+			x[j] = d.Bottom + (d.Top-d.Bottom)*(math.Sin(v)+1)/2 //nolint
+		default:
+			x[j] = d.Bottom + (d.Top-d.Bottom)/(1+math.Exp(-v))
+		}
+	}
+}
+
+// toY обратное преобразование y = T^-1(x), точки на границе отодвигаются внутрь.
+func (b *Bounded) toY(y, x []float64) {
+	for j, v := range x {
+		d := b.conf.FD.VarDomain(j)
+
+		if b.clamped(d) {
+			y[j] = v
+
+			continue
+		}
+
+		u := (v - d.Bottom) / (d.Top - d.Bottom)
+		u = math.Max(boundedInteriorTolerance, math.Min(1-boundedInteriorTolerance, u))
+
+		if b.conf.Mode == BoundedSin {
+			y[j] = math.Asin(2*u - 1) //nolint
+		} else {
+			y[j] = math.Log(u / (1 - u))
+		}
+	}
+}
+
+// derivatives первая и вторая производные x_j по y_j.
+func (b *Bounded) derivatives(j int, y float64) (float64, float64) {
+	d := b.conf.FD.VarDomain(j)
+	width := d.Top - d.Bottom
+
+	switch {
+	case b.clamped(d):
+		if d.Validate(y) != nil {
+			return 0, 0
+		}
+
+		return 1, 0
+	case b.conf.Mode == BoundedSin:
+		return width * math.Cos(y) / 2, -width * math.Sin(y) / 2 //nolint
+	default:
+		s := 1 / (1 + math.Exp(-y))
+
+		return width * s * (1 - s), width * s * (1 - s) * (1 - 2*s) //nolint
+	}
+}
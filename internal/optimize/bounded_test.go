@@ -0,0 +1,165 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// boundedProblem (x - 3)^2 + (y + 2)^2 + x*y, безусловный минимум -111/9 в (16/3, -14/3), на [-1, 1]^2
+// минимум 4 в (1, -1). outside считает вычисления функции вне области определения.
+func boundedProblem(d functions.VarDomain, outside *int) optimize.Problem {
+	return optimize.Problem{
+		Func: func(x []float64) float64 {
+			for _, v := range x {
+				if d.Validate(v) != nil {
+					*outside++
+				}
+			}
+
+			return (x[0]-3)*(x[0]-3) + (x[1]+2)*(x[1]+2) + x[0]*x[1]
+		},
+		Grad: func(grad, x []float64) {
+			grad[0] = 2*(x[0]-3) + x[1]
+			grad[1] = 2*(x[1]+2) + x[0]
+		},
+		Hess: func(dst *mat.SymDense, x []float64) {
+			dst.SetSym(0, 0, 2)
+			dst.SetSym(0, 1, 1)
+			dst.SetSym(1, 1, 2)
+		},
+	}
+}
+
+func TestBounded_Run(t *testing.T) {
+	modes := []struct {
+		name string
+		mode internaloptimize.BoundedMode
+		kink bool // излом на границе, линейный поиск может не сойтись у минимума.
+	}{
+		{name: "Sin", mode: internaloptimize.BoundedSin},
+		{name: "Logistic", mode: internaloptimize.BoundedLogistic},
+		{name: "Projection", mode: internaloptimize.BoundedProjection},
+		{name: "Penalty", mode: internaloptimize.BoundedPenalty, kink: true},
+	}
+	methods := []struct {
+		name      string
+		newMethod func() optimize.Method
+	}{
+		{name: "NelderMead", newMethod: func() optimize.Method { return &optimize.NelderMead{} }},
+		{name: "BFGS", newMethod: func() optimize.Method { return &optimize.BFGS{} }},
+		{name: "Newton", newMethod: func() optimize.Method { return &optimize.Newton{} }},
+		{name: "CmaEsChol", newMethod: func() optimize.Method { return &optimize.CmaEsChol{Src: rand.NewSource(1)} }},
+	}
+
+	for _, mode := range modes {
+		for _, method := range methods {
+			t.Run(mode.name+"/"+method.name, func(t *testing.T) {
+				asserting := assert.New(t)
+
+				d := functions.VarDomain{Bottom: -1, Top: 1}
+				conf := internaloptimize.DefaultBoundedConfig()
+				conf.FD = functions.NewSingleFuncDomain(d)
+				conf.Mode = mode.mode
+
+				outside := 0
+				prob := boundedProblem(d, &outside)
+				// CmaEsChol сообщает лучшую точку поколения, и критерий остановки по умолчанию завершает его
+				// у излома на границе раньше сходимости, поэтому методы останавливаются сами или по бюджету.
+				settings := &optimize.Settings{FuncEvaluations: 5_000, Converger: optimize.NeverTerminate{}}
+				bounded := internaloptimize.MustBounded(method.newMethod(), conf)
+
+				result, err := optimize.Minimize(prob, []float64{0.5, 0.5}, settings, bounded)
+				if !mode.kink {
+					asserting.NoError(err)
+				}
+
+				asserting.Zero(outside, "function must be evaluated only inside domain")
+				asserting.InDelta(4, result.F, 1e-3)
+				asserting.InDelta(1, result.X[0], 1e-2)
+				asserting.InDelta(-1, result.X[1], 1e-2)
+			})
+		}
+	}
+}
+
+func TestBounded_Gradient(t *testing.T) {
+	// метод Ньютона сходится к внутреннему минимуму после замены переменной, только если градиент
+	// и гессиан пересчитаны в пространство y согласованно.
+	asserting := assert.New(t)
+
+	d := functions.VarDomain{Bottom: -10, Top: 10}
+	conf := internaloptimize.DefaultBoundedConfig()
+	conf.FD = functions.NewSingleFuncDomain(d)
+
+	outside := 0
+	prob := boundedProblem(d, &outside)
+
+	for _, mode := range []internaloptimize.BoundedMode{internaloptimize.BoundedSin, internaloptimize.BoundedLogistic} {
+		conf.Mode = mode
+
+		result, err := optimize.Minimize(prob, []float64{0, 0}, nil, internaloptimize.MustBounded(&optimize.Newton{}, conf))
+		asserting.NoError(err)
+		asserting.InDelta(-111.0/9, result.F, 1e-8)
+		asserting.InDelta(16.0/3, result.X[0], 1e-4)
+		asserting.InDelta(-14.0/3, result.X[1], 1e-4)
+	}
+}
+
+func TestBoundedConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.BoundedConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.BoundedConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.BoundedConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "UnknownMode",
+			modify: func(c *internaloptimize.BoundedConfig) { c.Mode = 10 },
+			err:    internaloptimize.ErrBoundedMode,
+		},
+		{
+			name: "ZeroPenalty",
+			modify: func(c *internaloptimize.BoundedConfig) {
+				c.Mode = internaloptimize.BoundedPenalty
+				c.Penalty = 0
+			},
+			err: internaloptimize.ErrBoundedPenalty,
+		},
+		{
+			name: "PenaltyNotNeeded",
+			modify: func(c *internaloptimize.BoundedConfig) {
+				c.Mode = internaloptimize.BoundedProjection
+				c.Penalty = 0
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultBoundedConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewBounded(&optimize.NelderMead{}, conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+
+	_, err := internaloptimize.NewBounded(nil, nil)
+	assert.True(t, errors.Is(err, internaloptimize.ErrBoundedMethod))
+}
@@ -0,0 +1,232 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultCuckooNests        = 25
+	DefaultCuckooDiscovery    = 0.25
+	DefaultCuckooStepScale    = 0.01
+	DefaultCuckooLevyExponent = 1.5
+)
+
+var ErrCuckooLevyExponent = errors.New("levy exponent must be in (0, 2]")
+
+// CuckooConfig настройки поиска кукушки.
+type CuckooConfig struct {
+	FD           functions.FuncDomain
+	Nests        int
+	Discovery    float64 // вероятность pa, что хозяин гнезда обнаружит чужое яйцо.
+	StepScale    float64 // масштаб полета Леви относительно расстояния до лучшего гнезда.
+	LevyExponent float64 // показатель beta распределения Леви.
+	Seed         uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultCuckooConfig() *CuckooConfig {
+	return &CuckooConfig{
+		FD:           DefaultHSFD,
+		Nests:        DefaultCuckooNests,
+		Discovery:    DefaultCuckooDiscovery,
+		StepScale:    DefaultCuckooStepScale,
+		LevyExponent: DefaultCuckooLevyExponent,
+	}
+}
+
+func (c *CuckooConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.Nests < 2 { //nolint
+		return fmt.Errorf("%w: Nests %d", ErrConfigPopulationSize, c.Nests)
+	}
+
+	if !(0 <= c.Discovery && c.Discovery <= 1) {
+		return fmt.Errorf("%w: Discovery %v", ErrConfigProbability, c.Discovery)
+	}
+
+	if !(c.StepScale >= 0) {
+		return fmt.Errorf("%w: StepScale %v", ErrConfigNegative, c.StepScale)
+	}
+
+	if !(0 < c.LevyExponent && c.LevyExponent <= 2) {
+		return fmt.Errorf("%w: %v", ErrCuckooLevyExponent, c.LevyExponent)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*Cuckoo)(nil)
+
+// Cuckoo поиск кукушки (Cuckoo Search, Yang, Deb).
+//
+// Поколения чередуются: кукушки откладывают яйца полетом Леви от своих гнезд, затем хозяева
+// обнаруживают часть яиц и гнезда смещаются случайным блужданием. Новое гнездо заменяет старое, если лучше.
+// Гнезда поколения вычисляются одновременно в optimize.Settings.Concurrent задачах.
+type Cuckoo struct {
+	populationMethod
+	conf  *CuckooConfig
+	state *cuckooState
+}
+
+// cuckooPhase поколение поиска кукушки.
+type cuckooPhase int
+
+const (
+	cuckooInit      cuckooPhase = iota // вычисление начальных гнезд.
+	cuckooLevy                         // полеты Леви.
+	cuckooDiscovery                    // обнаружение яиц.
+)
+
+type cuckooState struct {
+	dim   int
+	rnd   *random.Generator
+	phase cuckooPhase
+
+	nests []*memoryComponent // гнезда.
+	eggs  [][]float64        // новые гнезда поколения.
+}
+
+// NewCuckoo создать экземпляр поиска кукушки. Настройки conf, по умолчанию DefaultCuckooConfig, копируются.
+func NewCuckoo(conf *CuckooConfig) (*Cuckoo, error) {
+	if conf == nil {
+		conf = DefaultCuckooConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Cuckoo{conf: &c}, nil
+}
+
+func MustCuckoo(conf *CuckooConfig) *Cuckoo {
+	c, err := NewCuckoo(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func (c *Cuckoo) Init(dim, tasks int) int {
+	s := &cuckooState{
+		dim:   dim,
+		rnd:   random.NewGenerator(c.conf.Seed),
+		nests: make([]*memoryComponent, c.conf.Nests),
+		eggs:  make([][]float64, c.conf.Nests),
+	}
+
+	for i := range s.eggs {
+		s.eggs[i] = make([]float64, dim)
+	}
+
+	c.state = s
+
+	return c.init(c, tasks)
+}
+
+func (c *Cuckoo) start(x []float64) {
+	s := c.state
+
+	for i := range s.nests {
+		if i == 0 {
+			s.nests[i] = &memoryComponent{X: normalizePoint(x, c.conf.FD)}
+		} else {
+			s.nests[i] = &memoryComponent{X: randomPoint(s.rnd, s.dim, c.conf.FD)}
+		}
+	}
+
+	s.phase = cuckooInit
+}
+
+func (c *Cuckoo) ask() [][]float64 {
+	s := c.state
+
+	switch s.phase {
+	case cuckooInit:
+		xs := make([][]float64, len(s.nests))
+		for i, nest := range s.nests {
+			xs[i] = nest.X
+		}
+
+		return xs
+	case cuckooLevy:
+		for i := range s.nests {
+			c.levyFlight(i, s.eggs[i])
+		}
+	default:
+		for i := range s.nests {
+			c.discover(i, s.eggs[i])
+		}
+	}
+
+	return s.eggs
+}
+
+func (c *Cuckoo) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := c.state
+
+	if s.phase == cuckooInit {
+		for i, f := range fs {
+			s.nests[i].F = f
+		}
+
+		s.phase = cuckooLevy
+
+		return optimize.NotTerminated, nil
+	}
+
+	for i, f := range fs {
+		if f < s.nests[i].F {
+			copy(s.nests[i].X, xs[i])
+			s.nests[i].F = f
+		}
+	}
+
+	if s.phase == cuckooLevy {
+		s.phase = cuckooDiscovery
+	} else {
+		s.phase = cuckooLevy
+	}
+
+	return optimize.NotTerminated, nil
+}
+
+// levyFlight яйцо кукушки из гнезда i: полет Леви, масштабированный расстоянием до лучшего гнезда.
+func (c *Cuckoo) levyFlight(i int, egg []float64) {
+	s := c.state
+	x := s.nests[i].X
+	best := c.best.X
+
+	for j := range egg {
+		d := c.conf.FD.VarDomain(j)
+		step := c.conf.StepScale * s.rnd.LevyStep(c.conf.LevyExponent) * (x[j] - best[j])
+		egg[j] = d.Normalize(x[j] + step*s.rnd.NormFloat64())
+	}
+}
+
+// discover гнездо i после обнаружения: каждая переменная с вероятностью Discovery смещается
+// на случайную долю разности двух случайных гнезд.
+func (c *Cuckoo) discover(i int, egg []float64) {
+	s := c.state
+	x := s.nests[i].X
+	a, b := s.nests[s.rnd.Intn(len(s.nests))].X, s.nests[s.rnd.Intn(len(s.nests))].X
+	r := s.rnd.Float64()
+
+	for j := range egg {
+		egg[j] = x[j]
+
+		if s.rnd.Float64() < c.conf.Discovery {
+			d := c.conf.FD.VarDomain(j)
+			egg[j] = d.Normalize(x[j] + r*(a[j]-b[j]))
+		}
+	}
+}
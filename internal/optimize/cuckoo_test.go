@@ -0,0 +1,98 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestCuckoo_Run(t *testing.T) {
+	problems := []struct {
+		name    string
+		exp     string
+		minimum float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, minimum: 0},
+		{name: "Levi13", exp: functions.Levi13, minimum: 0},
+		{name: "Matias", exp: functions.Matias, minimum: 0},
+	}
+
+	for _, problem := range problems {
+		t.Run(problem.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			d := functions.VarDomain{Bottom: -10, Top: 10}
+			conf := internaloptimize.DefaultCuckooConfig()
+			conf.FD = functions.NewSingleFuncDomain(d)
+			conf.Seed = 3
+
+			prob := functions.MustProblem(problem.exp, nil, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 10_000,
+				Concurrent:      4,
+			}
+
+			result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustCuckoo(conf))
+			asserting.NoError(err)
+			asserting.InDelta(problem.minimum, result.F, 1e-4)
+
+			for _, v := range result.X {
+				asserting.NoError(d.Validate(v))
+			}
+		})
+	}
+}
+
+func TestCuckooConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.CuckooConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.CuckooConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.CuckooConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "SingleNest",
+			modify: func(c *internaloptimize.CuckooConfig) { c.Nests = 1 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "DiscoveryAboveOne",
+			modify: func(c *internaloptimize.CuckooConfig) { c.Discovery = 1.5 },
+			err:    internaloptimize.ErrConfigProbability,
+		},
+		{
+			name:   "NegativeStep",
+			modify: func(c *internaloptimize.CuckooConfig) { c.StepScale = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name:   "LevyExponent",
+			modify: func(c *internaloptimize.CuckooConfig) { c.LevyExponent = 3 },
+			err:    internaloptimize.ErrCuckooLevyExponent,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultCuckooConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewCuckoo(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
@@ -0,0 +1,573 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultDEPopulationSize = 50
+	DefaultDEScale          = 0.5
+	DefaultDECrossover      = 0.9
+	DefaultDEPBest          = 0.1
+	DefaultDELearningRate   = 0.1
+	DefaultDEHistorySize    = 10
+)
+
+var (
+	ErrDEStrategy   = errors.New("unknown differential evolution strategy")
+	ErrDEAdaptation = errors.New("unknown differential evolution adaptation")
+)
+
+// DEStrategy способ построения мутантного вектора и скрещивания.
+type DEStrategy int
+
+const (
+	// DERand1Bin v = x_r1 + F*(x_r2 - x_r3), биномиальное скрещивание.
+	DERand1Bin DEStrategy = iota
+	// DEBest1Bin v = x_best + F*(x_r1 - x_r2), биномиальное скрещивание.
+	DEBest1Bin
+	// DECurrentToBest1Bin v = x_i + F*(x_best - x_i) + F*(x_r1 - x_r2), биномиальное скрещивание.
+	DECurrentToBest1Bin
+	// DERand2Exp v = x_r1 + F*(x_r2 - x_r3) + F*(x_r4 - x_r5), экспоненциальное скрещивание.
+	DERand2Exp
+)
+
+// DEAdaptation подбор F и CR.
+type DEAdaptation int
+
+const (
+	// DEFixed F и CR из настроек.
+	DEFixed DEAdaptation = iota
+	// DEJADE F и CR каждой особи вокруг средних, которые сдвигаются к успешным значениям (Zhang, Sanderson).
+	DEJADE
+	// DESHADE F и CR каждой особи вокруг одной из записей истории успешных значений (Tanabe, Fukunaga).
+	DESHADE
+)
+
+// DEConfig настройки дифференциальной эволюции.
+//
+// При DEJADE и DESHADE стратегия Strategy не используется, мутация current-to-pbest/1 с архивом
+// вытесненных родителей и биномиальное скрещивание.
+type DEConfig struct {
+	FD             functions.FuncDomain
+	PopulationSize int
+	Strategy       DEStrategy
+	Scale          float64 // коэффициент мутации F, стартовое среднее при адаптации.
+	Crossover      float64 // вероятность скрещивания CR, стартовое среднее при адаптации.
+	Adaptation     DEAdaptation
+	PBest          float64 // доля лучших особей, из которых берется x_pbest при адаптации.
+	LearningRate   float64 // скорость сдвига средних для DEJADE.
+	HistorySize    int     // размер истории успешных значений для DESHADE.
+	Seed           uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultDEConfig() *DEConfig {
+	return &DEConfig{
+		FD:             DefaultHSFD,
+		PopulationSize: DefaultDEPopulationSize,
+		Strategy:       DERand1Bin,
+		Scale:          DefaultDEScale,
+		Crossover:      DefaultDECrossover,
+		Adaptation:     DEFixed,
+		PBest:          DefaultDEPBest,
+		LearningRate:   DefaultDELearningRate,
+		HistorySize:    DefaultDEHistorySize,
+	}
+}
+
+func (c *DEConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.Strategy < DERand1Bin || c.Strategy > DERand2Exp {
+		return fmt.Errorf("%w: %d", ErrDEStrategy, c.Strategy)
+	}
+
+	if c.Adaptation < DEFixed || c.Adaptation > DESHADE {
+		return fmt.Errorf("%w: %d", ErrDEAdaptation, c.Adaptation)
+	}
+
+	if min := c.minPopulationSize(); c.PopulationSize < min {
+		return fmt.Errorf("%w: PopulationSize %d, at least %d", ErrConfigPopulationSize, c.PopulationSize, min)
+	}
+
+	if !(c.Scale >= 0) {
+		return fmt.Errorf("%w: Scale %v", ErrConfigNegative, c.Scale)
+	}
+
+	probs := []struct {
+		name string
+		p    float64
+	}{
+		{name: "Crossover", p: c.Crossover},
+		{name: "PBest", p: c.PBest},
+		{name: "LearningRate", p: c.LearningRate},
+	}
+
+	for _, prob := range probs {
+		if !(0 <= prob.p && prob.p <= 1) {
+			return fmt.Errorf("%w: %s %v", ErrConfigProbability, prob.name, prob.p)
+		}
+	}
+
+	if c.Adaptation == DESHADE && c.HistorySize < 1 {
+		return fmt.Errorf("%w: HistorySize %d", ErrConfigPopulationSize, c.HistorySize)
+	}
+
+	return nil
+}
+
+// minPopulationSize наименьший размер популяции, при котором найдутся различные особи для мутации.
+func (c *DEConfig) minPopulationSize() int {
+	if c.Adaptation == DEFixed && c.Strategy == DERand2Exp {
+		return 6 //nolint
+	}
+
+	return 4 //nolint
+}
+
+var _ optimize.Method = (*DE)(nil)
+
+// DE метод дифференциальной эволюции (Differential Evolution).
+//
+// Пробные особи поколения вычисляются одновременно в optimize.Settings.Concurrent задачах.
+// Вышедшая за FD переменная заменяется серединой между родителем и границей.
+type DE struct {
+	populationMethod
+	conf  *DEConfig
+	state *deState
+}
+
+type deState struct {
+	dim int
+	rnd *random.Generator
+
+	pop    [][]float64 // популяция.
+	popF   []float64   // значения популяции.
+	trials [][]float64 // пробные особи поколения.
+	scales []float64   // F пробных особей.
+	cross  []float64   // CR пробных особей.
+
+	archive [][]float64 // вытесненные родители для DEJADE и DESHADE.
+
+	meanScale float64   // среднее F для DEJADE.
+	meanCross float64   // среднее CR для DEJADE.
+	histScale []float64 // история F для DESHADE.
+	histCross []float64 // история CR для DESHADE.
+	histIndex int       // обновляемая запись истории.
+
+	initialized bool // вычислена ли начальная популяция.
+}
+
+// NewDE создать экземпляр метода дифференциальной эволюции. Настройки conf, по умолчанию DefaultDEConfig, копируются.
+func NewDE(conf *DEConfig) (*DE, error) {
+	if conf == nil {
+		conf = DefaultDEConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &DE{conf: &c}, nil
+}
+
+func MustDE(conf *DEConfig) *DE {
+	d, err := NewDE(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func (d *DE) Init(dim, tasks int) int {
+	c := d.conf
+	s := &deState{
+		dim:       dim,
+		rnd:       random.NewGenerator(c.Seed),
+		pop:       make([][]float64, c.PopulationSize),
+		popF:      make([]float64, c.PopulationSize),
+		trials:    make([][]float64, c.PopulationSize),
+		scales:    make([]float64, c.PopulationSize),
+		cross:     make([]float64, c.PopulationSize),
+		meanScale: c.Scale,
+		meanCross: c.Crossover,
+	}
+
+	if c.Adaptation == DESHADE {
+		s.histScale = make([]float64, c.HistorySize)
+		s.histCross = make([]float64, c.HistorySize)
+
+		for i := range s.histScale {
+			s.histScale[i] = c.Scale
+			s.histCross[i] = c.Crossover
+		}
+	}
+
+	for i := range s.trials {
+		s.trials[i] = make([]float64, dim)
+	}
+
+	d.state = s
+
+	return d.init(d, tasks)
+}
+
+func (d *DE) start(x []float64) {
+	s := d.state
+
+	for i := range s.pop {
+		if i == 0 {
+			s.pop[i] = normalizePoint(x, d.conf.FD)
+		} else {
+			s.pop[i] = randomPoint(s.rnd, s.dim, d.conf.FD)
+		}
+	}
+
+	s.initialized = false
+}
+
+func (d *DE) ask() [][]float64 {
+	s := d.state
+
+	if !s.initialized {
+		return s.pop
+	}
+
+	best := bestIndex(s.popF)
+	order := argsort(s.popF)
+
+	for i := range s.pop {
+		d.params(i)
+
+		if d.conf.Adaptation != DEFixed {
+			d.currentToPBest(i, order)
+
+			continue
+		}
+
+		d.mutate(i, best)
+	}
+
+	return s.trials
+}
+
+func (d *DE) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := d.state
+
+	if !s.initialized {
+		copy(s.popF, fs)
+		s.initialized = true
+
+		return optimize.NotTerminated, nil
+	}
+
+	var successScales, successCross, improvements []float64
+
+	for i, f := range fs {
+		if f > s.popF[i] {
+			continue
+		}
+
+		if f < s.popF[i] {
+			successScales = append(successScales, s.scales[i])
+			successCross = append(successCross, s.cross[i])
+			improvements = append(improvements, s.popF[i]-f)
+
+			d.toArchive(s.pop[i])
+		}
+
+		copy(s.pop[i], xs[i])
+		s.popF[i] = f
+	}
+
+	d.adapt(successScales, successCross, improvements)
+
+	return optimize.NotTerminated, nil
+}
+
+// params F и CR пробной особи i.
+func (d *DE) params(i int) {
+	s := d.state
+
+	switch d.conf.Adaptation {
+	case DEJADE:
+		s.scales[i] = d.cauchyScale(s.meanScale)
+		s.cross[i] = normalProb(s.rnd, s.meanCross, 0.1) //nolint
+	case DESHADE:
+		k := s.rnd.Intn(len(s.histScale))
+		s.scales[i] = d.cauchyScale(s.histScale[k])
+		s.cross[i] = normalProb(s.rnd, s.histCross[k], 0.1) //nolint
+	default:
+		s.scales[i] = d.conf.Scale
+		s.cross[i] = d.conf.Crossover
+	}
+}
+
+// cauchyScale F из распределения Коши вокруг mean, повторяется пока не положительно, ограничено 1.
+func (d *DE) cauchyScale(mean float64) float64 {
+	for {
+		f := mean + d.state.rnd.CauchyStep(0.1) //nolint
+		if f > 1 {
+			return 1
+		}
+
+		if f > 0 {
+			return f
+		}
+	}
+}
+
+// mutate пробная особь i по стратегии из настроек.
+func (d *DE) mutate(i, best int) {
+	s := d.state
+	f := s.scales[i]
+	x := s.pop[i]
+	v := make([]float64, s.dim)
+
+	switch d.conf.Strategy {
+	case DEBest1Bin:
+		r := d.distinct(2, i, best)
+		for j := range v {
+			v[j] = s.pop[best][j] + f*(s.pop[r[0]][j]-s.pop[r[1]][j])
+		}
+	case DECurrentToBest1Bin:
+		r := d.distinct(2, i, best)
+		for j := range v {
+			v[j] = x[j] + f*(s.pop[best][j]-x[j]) + f*(s.pop[r[0]][j]-s.pop[r[1]][j])
+		}
+	case DERand2Exp:
+		r := d.distinct(5, i)
+		for j := range v {
+			v[j] = s.pop[r[0]][j] + f*(s.pop[r[1]][j]-s.pop[r[2]][j]) + f*(s.pop[r[3]][j]-s.pop[r[4]][j])
+		}
+
+		d.exponentialCrossover(i, v)
+
+		return
+	default:
+		r := d.distinct(3, i)
+		for j := range v {
+			v[j] = s.pop[r[0]][j] + f*(s.pop[r[1]][j]-s.pop[r[2]][j])
+		}
+	}
+
+	d.binomialCrossover(i, v)
+}
+
+// currentToPBest пробная особь i по current-to-pbest/1 с архивом, order - индексы популяции от лучшей.
+func (d *DE) currentToPBest(i int, order []int) {
+	s := d.state
+	f := s.scales[i]
+	x := s.pop[i]
+
+	p := int(math.Round(d.conf.PBest * float64(len(s.pop))))
+	if p < 1 {
+		p = 1
+	}
+
+	pbest := s.pop[order[s.rnd.Intn(p)]]
+	r1 := d.distinct(1, i)[0]
+
+	// вторая особь из объединения популяции и архива.
+	var x2 []float64
+
+	for {
+		r2 := s.rnd.Intn(len(s.pop) + len(s.archive))
+		if r2 >= len(s.pop) {
+			x2 = s.archive[r2-len(s.pop)]
+
+			break
+		}
+
+		if r2 != i && r2 != r1 {
+			x2 = s.pop[r2]
+
+			break
+		}
+	}
+
+	v := make([]float64, s.dim)
+	for j := range v {
+		v[j] = x[j] + f*(pbest[j]-x[j]) + f*(s.pop[r1][j]-x2[j])
+	}
+
+	d.binomialCrossover(i, v)
+}
+
+// binomialCrossover каждая переменная берется из мутанта с вероятностью CR, одна - обязательно.
+func (d *DE) binomialCrossover(i int, v []float64) {
+	s := d.state
+	jrand := s.rnd.Intn(s.dim)
+
+	for j := range v {
+		if j == jrand || s.rnd.Float64() < s.cross[i] {
+			s.trials[i][j] = d.bound(i, j, v[j])
+		} else {
+			s.trials[i][j] = s.pop[i][j]
+		}
+	}
+}
+
+// exponentialCrossover из мутанта берутся идущие подряд (по кругу) переменные, пока выпадает CR.
+func (d *DE) exponentialCrossover(i int, v []float64) {
+	s := d.state
+	copy(s.trials[i], s.pop[i])
+
+	j := s.rnd.Intn(s.dim)
+	for l := 0; l < s.dim; l++ {
+		s.trials[i][j] = d.bound(i, j, v[j])
+		j = (j + 1) % s.dim
+
+		if s.rnd.Float64() >= s.cross[i] {
+			break
+		}
+	}
+}
+
+// bound значение переменной j мутанта, вышедшее за область определения, заменяется серединой
+// между значением родителя i и границей.
+func (d *DE) bound(i, j int, v float64) float64 {
+	dom := d.conf.FD.VarDomain(j)
+
+	switch {
+	case v < dom.Bottom:
+		return (dom.Bottom + d.state.pop[i][j]) / 2 //nolint
+	case v > dom.Top:
+		return (dom.Top + d.state.pop[i][j]) / 2 //nolint
+	default:
+		return v
+	}
+}
+
+// distinct n различных индексов популяции, не совпадающих с exclude.
+func (d *DE) distinct(n int, exclude ...int) []int {
+	s := d.state
+	r := make([]int, 0, n)
+
+	for len(r) < n {
+		k := s.rnd.Intn(len(s.pop))
+		if containsInt(exclude, k) || containsInt(r, k) {
+			continue
+		}
+
+		r = append(r, k)
+	}
+
+	return r
+}
+
+// toArchive сохранение вытесненного родителя, архив не больше популяции.
+func (d *DE) toArchive(x []float64) {
+	s := d.state
+
+	if d.conf.Adaptation == DEFixed {
+		return
+	}
+
+	x = append([]float64(nil), x...)
+
+	if len(s.archive) < len(s.pop) {
+		s.archive = append(s.archive, x)
+
+		return
+	}
+
+	s.archive[s.rnd.Intn(len(s.archive))] = x
+}
+
+// adapt сдвиг параметров к успешным значениям, улучшения improvements - веса для DESHADE.
+func (d *DE) adapt(scales, cross, improvements []float64) {
+	s := d.state
+
+	if len(scales) == 0 {
+		return
+	}
+
+	switch d.conf.Adaptation {
+	case DEJADE:
+		c := d.conf.LearningRate
+		s.meanScale = (1-c)*s.meanScale + c*lehmerMean(scales, nil)
+		s.meanCross = (1-c)*s.meanCross + c*weightedMean(cross, nil)
+	case DESHADE:
+		s.histScale[s.histIndex] = lehmerMean(scales, improvements)
+		s.histCross[s.histIndex] = weightedMean(cross, improvements)
+		s.histIndex = (s.histIndex + 1) % len(s.histScale)
+	}
+}
+
+// lehmerMean взвешенное среднее Лемера sum(w*v^2)/sum(w*v), weights nil - равные веса.
+func lehmerMean(values, weights []float64) float64 {
+	var num, den float64
+
+	for i, v := range values {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+
+		num += w * v * v
+		den += w * v
+	}
+
+	if den == 0 {
+		return 0
+	}
+
+	return num / den
+}
+
+// weightedMean взвешенное среднее, weights nil - равные веса.
+func weightedMean(values, weights []float64) float64 {
+	var num, den float64
+
+	for i, v := range values {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+
+		num += w * v
+		den += w
+	}
+
+	if den == 0 {
+		return 0
+	}
+
+	return num / den
+}
+
+// argsort индексы значений fs по возрастанию.
+func argsort(fs []float64) []int {
+	order := make([]int, len(fs))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return fs[order[i]] < fs[order[j]]
+	})
+
+	return order
+}
+
+func containsInt(values []int, v int) bool {
+	for _, e := range values {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
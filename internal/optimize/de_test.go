@@ -0,0 +1,146 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestDE_Run(t *testing.T) {
+	schemes := []struct {
+		name       string
+		strategy   internaloptimize.DEStrategy
+		adaptation internaloptimize.DEAdaptation
+	}{
+		{name: "Rand1Bin", strategy: internaloptimize.DERand1Bin},
+		{name: "Best1Bin", strategy: internaloptimize.DEBest1Bin},
+		{name: "CurrentToBest1Bin", strategy: internaloptimize.DECurrentToBest1Bin},
+		{name: "Rand2Exp", strategy: internaloptimize.DERand2Exp},
+		{name: "JADE", adaptation: internaloptimize.DEJADE},
+		{name: "SHADE", adaptation: internaloptimize.DESHADE},
+	}
+	problems := []struct {
+		name    string
+		exp     string
+		minimum float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, minimum: 0},
+		{name: "Levi13", exp: functions.Levi13, minimum: 0},
+	}
+
+	for _, scheme := range schemes {
+		for _, problem := range problems {
+			t.Run(scheme.name+"/"+problem.name, func(t *testing.T) {
+				asserting := assert.New(t)
+
+				d := functions.VarDomain{Bottom: -10, Top: 10}
+				conf := internaloptimize.DefaultDEConfig()
+				conf.FD = functions.NewSingleFuncDomain(d)
+				conf.PopulationSize = 30
+				conf.Strategy = scheme.strategy
+				conf.Adaptation = scheme.adaptation
+				conf.Seed = 3
+
+				prob := functions.MustProblem(problem.exp, nil, nil)
+				settings := &optimize.Settings{
+					Converger:       optimize.NeverTerminate{},
+					FuncEvaluations: 10_000,
+					Concurrent:      4,
+				}
+
+				result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustDE(conf))
+				asserting.NoError(err)
+				asserting.InDelta(problem.minimum, result.F, 1e-4)
+
+				for _, v := range result.X {
+					asserting.NoError(d.Validate(v))
+				}
+			})
+		}
+	}
+}
+
+func TestDEConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.DEConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.DEConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.DEConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name: "SmallPopulationForRand2",
+			modify: func(c *internaloptimize.DEConfig) {
+				c.Strategy = internaloptimize.DERand2Exp
+				c.PopulationSize = 5
+			},
+			err: internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name: "SmallPopulationForBest1",
+			modify: func(c *internaloptimize.DEConfig) {
+				c.Strategy = internaloptimize.DEBest1Bin
+				c.PopulationSize = 3
+			},
+			err: internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name: "SmallPopulationForCurrentToBest1",
+			modify: func(c *internaloptimize.DEConfig) {
+				c.Strategy = internaloptimize.DECurrentToBest1Bin
+				c.PopulationSize = 3
+			},
+			err: internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "NegativeScale",
+			modify: func(c *internaloptimize.DEConfig) { c.Scale = -0.5 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name:   "CrossoverAboveOne",
+			modify: func(c *internaloptimize.DEConfig) { c.Crossover = 1.5 },
+			err:    internaloptimize.ErrConfigProbability,
+		},
+		{
+			name: "EmptyHistory",
+			modify: func(c *internaloptimize.DEConfig) {
+				c.Adaptation = internaloptimize.DESHADE
+				c.HistorySize = 0
+			},
+			err: internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "UnknownStrategy",
+			modify: func(c *internaloptimize.DEConfig) { c.Strategy = 10 },
+			err:    internaloptimize.ErrDEStrategy,
+		},
+		{
+			name:   "UnknownAdaptation",
+			modify: func(c *internaloptimize.DEConfig) { c.Adaptation = 10 },
+			err:    internaloptimize.ErrDEAdaptation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultDEConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewDE(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
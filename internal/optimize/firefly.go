@@ -0,0 +1,218 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultFireflyPopulationSize  = 25
+	DefaultFireflyAttractiveness  = 1
+	DefaultFireflyAbsorption      = 1
+	DefaultFireflyRandomness      = 0.2
+	DefaultFireflyRandomnessDecay = 0.97
+)
+
+// FireflyConfig настройки алгоритма светлячков.
+//
+// Расстояния и случайные шаги считаются в долях ширины области определения каждой переменной,
+// поэтому Absorption и Randomness не зависят от масштаба FD.
+type FireflyConfig struct {
+	FD              functions.FuncDomain
+	PopulationSize  int
+	Attractiveness  float64 // привлекательность beta0 на нулевом расстоянии.
+	Absorption      float64 // поглощение света gamma, привлекательность beta0*exp(-gamma*r^2).
+	Randomness      float64 // начальный вес случайного шага alpha0.
+	RandomnessDecay float64 // множитель alpha после каждого поколения.
+	Seed            uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultFireflyConfig() *FireflyConfig {
+	return &FireflyConfig{
+		FD:              DefaultHSFD,
+		PopulationSize:  DefaultFireflyPopulationSize,
+		Attractiveness:  DefaultFireflyAttractiveness,
+		Absorption:      DefaultFireflyAbsorption,
+		Randomness:      DefaultFireflyRandomness,
+		RandomnessDecay: DefaultFireflyRandomnessDecay,
+	}
+}
+
+func (c *FireflyConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.PopulationSize < 2 { //nolint
+		return fmt.Errorf("%w: PopulationSize %d", ErrConfigPopulationSize, c.PopulationSize)
+	}
+
+	nonNegative := []struct {
+		name string
+		v    float64
+	}{
+		{name: "Attractiveness", v: c.Attractiveness},
+		{name: "Absorption", v: c.Absorption},
+		{name: "Randomness", v: c.Randomness},
+	}
+
+	for _, v := range nonNegative {
+		if !(v.v >= 0) {
+			return fmt.Errorf("%w: %s %v", ErrConfigNegative, v.name, v.v)
+		}
+	}
+
+	if !(0 <= c.RandomnessDecay && c.RandomnessDecay <= 1) {
+		return fmt.Errorf("%w: RandomnessDecay %v", ErrConfigProbability, c.RandomnessDecay)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*Firefly)(nil)
+
+// Firefly алгоритм светлячков (Firefly Algorithm, Yang).
+//
+// Каждый светлячок летит ко всем более ярким, а самый яркий блуждает случайно. Светлячки поколения
+// перемещаются по положениям предыдущего и вычисляются одновременно в optimize.Settings.Concurrent задачах.
+type Firefly struct {
+	populationMethod
+	conf  *FireflyConfig
+	state *fireflyState
+}
+
+type fireflyState struct {
+	dim int
+	rnd *random.Generator
+
+	x     [][]float64 // положения светлячков.
+	f     []float64   // значения в положениях.
+	alpha float64     // текущий вес случайного шага.
+
+	evaluated bool // вычислены ли начальные положения.
+}
+
+// NewFirefly создать экземпляр алгоритма светлячков. Настройки conf, по умолчанию DefaultFireflyConfig, копируются.
+func NewFirefly(conf *FireflyConfig) (*Firefly, error) {
+	if conf == nil {
+		conf = DefaultFireflyConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Firefly{conf: &c}, nil
+}
+
+func MustFirefly(conf *FireflyConfig) *Firefly {
+	f, err := NewFirefly(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+func (f *Firefly) Init(dim, tasks int) int {
+	f.state = &fireflyState{
+		dim: dim,
+		rnd: random.NewGenerator(f.conf.Seed),
+		x:   make([][]float64, f.conf.PopulationSize),
+		f:   make([]float64, f.conf.PopulationSize),
+	}
+
+	return f.init(f, tasks)
+}
+
+func (f *Firefly) start(x []float64) {
+	s := f.state
+
+	for i := range s.x {
+		if i == 0 {
+			s.x[i] = normalizePoint(x, f.conf.FD)
+		} else {
+			s.x[i] = randomPoint(s.rnd, s.dim, f.conf.FD)
+		}
+	}
+
+	s.alpha = f.conf.Randomness
+	s.evaluated = false
+}
+
+func (f *Firefly) ask() [][]float64 {
+	s := f.state
+
+	if !s.evaluated {
+		return s.x
+	}
+
+	moved := make([][]float64, len(s.x))
+
+	for i := range s.x {
+		moved[i] = f.fly(i)
+	}
+
+	s.x = moved
+	s.alpha *= f.conf.RandomnessDecay
+
+	return s.x
+}
+
+func (f *Firefly) tell(_ [][]float64, fs []float64) (optimize.Status, error) {
+	s := f.state
+	copy(s.f, fs)
+	s.evaluated = true
+
+	return optimize.NotTerminated, nil
+}
+
+// fly новое положение светлячка i: притяжение ко всем более ярким и случайный шаг.
+func (f *Firefly) fly(i int) []float64 {
+	s := f.state
+	c := f.conf
+	x := append([]float64(nil), s.x[i]...)
+
+	for j := range s.x {
+		if s.f[j] >= s.f[i] {
+			continue
+		}
+
+		beta := c.Attractiveness * math.Exp(-c.Absorption*f.distance2(s.x[i], s.x[j]))
+
+		for k := range x {
+			x[k] += beta * (s.x[j][k] - x[k])
+		}
+	}
+
+	for k := range x {
+		d := c.FD.VarDomain(k)
+		x[k] = d.Normalize(x[k] + s.rnd.UniformStep(s.alpha/2)*(d.Top-d.Bottom)) //nolint
+	}
+
+	return x
+}
+
+// distance2 квадрат расстояния между точками в долях ширины области определения.
+func (f *Firefly) distance2(a, b []float64) float64 {
+	r2 := 0.0
+
+	for k := range a {
+		d := f.conf.FD.VarDomain(k)
+
+		width := d.Top - d.Bottom
+		if width <= 0 {
+			continue
+		}
+
+		r := (a[k] - b[k]) / width
+		r2 += r * r
+	}
+
+	return r2
+}
@@ -0,0 +1,93 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestFirefly_Run(t *testing.T) {
+	problems := []struct {
+		name    string
+		exp     string
+		minimum float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, minimum: 0},
+		{name: "Levi13", exp: functions.Levi13, minimum: 0},
+		{name: "Matias", exp: functions.Matias, minimum: 0},
+	}
+
+	for _, problem := range problems {
+		t.Run(problem.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			d := functions.VarDomain{Bottom: -10, Top: 10}
+			conf := internaloptimize.DefaultFireflyConfig()
+			conf.FD = functions.NewSingleFuncDomain(d)
+			conf.Seed = 3
+
+			prob := functions.MustProblem(problem.exp, nil, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 10_000,
+				Concurrent:      4,
+			}
+
+			result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustFirefly(conf))
+			asserting.NoError(err)
+			asserting.InDelta(problem.minimum, result.F, 1e-4)
+
+			for _, v := range result.X {
+				asserting.NoError(d.Validate(v))
+			}
+		})
+	}
+}
+
+func TestFireflyConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.FireflyConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.FireflyConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.FireflyConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "SingleFirefly",
+			modify: func(c *internaloptimize.FireflyConfig) { c.PopulationSize = 1 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "NegativeAbsorption",
+			modify: func(c *internaloptimize.FireflyConfig) { c.Absorption = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name:   "GrowingRandomness",
+			modify: func(c *internaloptimize.FireflyConfig) { c.RandomnessDecay = 1.1 },
+			err:    internaloptimize.ErrConfigProbability,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultFireflyConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewFirefly(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
@@ -0,0 +1,529 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultGAPopulationSize = 50
+	DefaultGACrossoverProb  = 0.9
+	DefaultGAElitism        = 2
+	DefaultGATournamentSize = 2
+	DefaultGARankPressure   = 1.5
+	DefaultGASBXEta         = 20
+	DefaultGABLXAlpha       = 0.5
+	DefaultGAPolynomialEta  = 20
+	DefaultGAGaussianSigma  = 0.1
+)
+
+var (
+	ErrGAOperator       = errors.New("genetic operator is not set")
+	ErrGAElitism        = errors.New("elitism must be less than population size")
+	ErrGARankPressure   = errors.New("rank selection pressure must be in [1, 2]")
+	ErrGATournamentSize = errors.New("tournament size must be positive")
+)
+
+// GASelection выбор родителей.
+type GASelection interface {
+	// Select индексы n родителей, выбранных по значениям популяции fs (меньше - лучше).
+	Select(rnd *random.Generator, fs []float64, n int) []int
+}
+
+// GACrossover скрещивание родителей.
+type GACrossover interface {
+	// Crossover запись в c1 и c2 потомков родителей p1 и p2, потомки должны лежать в fd.
+	Crossover(rnd *random.Generator, fd functions.FuncDomain, p1, p2, c1, c2 []float64)
+}
+
+// GAMutation мутация потомка.
+type GAMutation interface {
+	// Mutate изменение x на месте, x должен остаться в fd.
+	Mutate(rnd *random.Generator, fd functions.FuncDomain, x []float64)
+}
+
+// validator оператор, параметры которого проверяются вместе с настройками.
+type validator interface {
+	Validate() error
+}
+
+// GATournament турнирный отбор: лучшая из Size случайных особей.
+type GATournament struct {
+	Size int
+}
+
+func (t GATournament) Validate() error {
+	if t.Size < 1 {
+		return fmt.Errorf("%w: %d", ErrGATournamentSize, t.Size)
+	}
+
+	return nil
+}
+
+func (t GATournament) Select(rnd *random.Generator, fs []float64, n int) []int {
+	parents := make([]int, n)
+
+	for i := range parents {
+		best := rnd.Intn(len(fs))
+
+		for k := 1; k < t.Size; k++ {
+			if j := rnd.Intn(len(fs)); fs[j] < fs[best] {
+				best = j
+			}
+		}
+
+		parents[i] = best
+	}
+
+	return parents
+}
+
+// GARoulette отбор рулеткой, вес особи - насколько ее значение меньше худшего в популяции.
+type GARoulette struct{}
+
+func (GARoulette) Select(rnd *random.Generator, fs []float64, n int) []int {
+	worst := fs[0]
+	for _, f := range fs {
+		worst = math.Max(worst, f)
+	}
+
+	weights := make([]float64, len(fs))
+	for i, f := range fs {
+		weights[i] = worst - f
+	}
+
+	return rouletteSelect(rnd, weights, n)
+}
+
+// GARank линейный ранговый отбор, Pressure - во сколько раз вероятность выбора лучшей особи
+// больше средней.
+type GARank struct {
+	Pressure float64
+}
+
+func (r GARank) Validate() error {
+	if !(1 <= r.Pressure && r.Pressure <= 2) {
+		return fmt.Errorf("%w: %v", ErrGARankPressure, r.Pressure)
+	}
+
+	return nil
+}
+
+func (r GARank) Select(rnd *random.Generator, fs []float64, n int) []int {
+	size := len(fs)
+	order := argsort(fs)
+	weights := make([]float64, size)
+
+	for rank, i := range order {
+		// rank 0 - лучшая особь.
+		weights[i] = r.Pressure
+		if size > 1 {
+			weights[i] -= 2 * (r.Pressure - 1) * float64(rank) / float64(size-1)
+		}
+	}
+
+	return rouletteSelect(rnd, weights, n)
+}
+
+// rouletteSelect n индексов с вероятностями, пропорциональными weights. Нулевые веса - равные вероятности.
+func rouletteSelect(rnd *random.Generator, weights []float64, n int) []int {
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+
+	for i, w := range weights {
+		total += w
+		cumulative[i] = total
+	}
+
+	parents := make([]int, n)
+
+	for i := range parents {
+		if !(total > 0) {
+			parents[i] = rnd.Intn(len(weights))
+
+			continue
+		}
+
+		j := sort.SearchFloat64s(cumulative, rnd.Float64()*total)
+		if j == len(weights) {
+			j--
+		}
+
+		parents[i] = j
+	}
+
+	return parents
+}
+
+// GASBX имитация двоичного скрещивания (Simulated Binary Crossover, Deb, Agrawal),
+// чем больше Eta, тем ближе потомки к родителям.
+type GASBX struct {
+	Eta float64
+}
+
+func (x GASBX) Validate() error {
+	if !(x.Eta >= 0) {
+		return fmt.Errorf("%w: Eta %v", ErrConfigNegative, x.Eta)
+	}
+
+	return nil
+}
+
+func (x GASBX) Crossover(rnd *random.Generator, fd functions.FuncDomain, p1, p2, c1, c2 []float64) {
+	for j := range p1 {
+		u := rnd.Float64()
+
+		var beta float64
+		if u <= 0.5 { //nolint
+			beta = math.Pow(2*u, 1/(x.Eta+1))
+		} else {
+			beta = math.Pow(1/(2*(1-u)), 1/(x.Eta+1))
+		}
+
+		d := fd.VarDomain(j)
+		c1[j] = d.Normalize(0.5 * ((1+beta)*p1[j] + (1-beta)*p2[j])) //nolint
+		c2[j] = d.Normalize(0.5 * ((1-beta)*p1[j] + (1+beta)*p2[j])) //nolint
+	}
+}
+
+// GABLXAlpha скрещивание смешиванием: потомки равномерно в отрезке родителей,
+// расширенном на Alpha его длины с каждой стороны.
+type GABLXAlpha struct {
+	Alpha float64
+}
+
+func (x GABLXAlpha) Validate() error {
+	if !(x.Alpha >= 0) {
+		return fmt.Errorf("%w: Alpha %v", ErrConfigNegative, x.Alpha)
+	}
+
+	return nil
+}
+
+func (x GABLXAlpha) Crossover(rnd *random.Generator, fd functions.FuncDomain, p1, p2, c1, c2 []float64) {
+	for j := range p1 {
+		lo, hi := math.Min(p1[j], p2[j]), math.Max(p1[j], p2[j])
+		ext := x.Alpha * (hi - lo)
+		d := fd.VarDomain(j)
+
+		c1[j] = d.Normalize(rnd.FloatInRange(lo-ext, hi+ext))
+		c2[j] = d.Normalize(rnd.FloatInRange(lo-ext, hi+ext))
+	}
+}
+
+// GAArithmetic арифметическое скрещивание: потомки - выпуклые комбинации родителей со случайным весом.
+type GAArithmetic struct{}
+
+func (GAArithmetic) Crossover(rnd *random.Generator, _ functions.FuncDomain, p1, p2, c1, c2 []float64) {
+	w := rnd.Float64()
+
+	for j := range p1 {
+		c1[j] = w*p1[j] + (1-w)*p2[j]
+		c2[j] = (1-w)*p1[j] + w*p2[j]
+	}
+}
+
+// GAPolynomialMutation полиномиальная мутация (Deb), чем больше Eta, тем меньше изменения.
+// Rate - вероятность мутации переменной, 0 - 1/размерность.
+type GAPolynomialMutation struct {
+	Eta  float64
+	Rate float64
+}
+
+func (m GAPolynomialMutation) Validate() error {
+	if !(m.Eta >= 0) {
+		return fmt.Errorf("%w: Eta %v", ErrConfigNegative, m.Eta)
+	}
+
+	if !(0 <= m.Rate && m.Rate <= 1) {
+		return fmt.Errorf("%w: Rate %v", ErrConfigProbability, m.Rate)
+	}
+
+	return nil
+}
+
+func (m GAPolynomialMutation) Mutate(rnd *random.Generator, fd functions.FuncDomain, x []float64) {
+	rate := mutationRate(m.Rate, len(x))
+
+	for j := range x {
+		if rnd.Float64() >= rate {
+			continue
+		}
+
+		d := fd.VarDomain(j)
+		width := d.Top - d.Bottom
+
+		if width <= 0 {
+			continue
+		}
+
+		u := rnd.Float64()
+		power := 1 / (m.Eta + 1)
+
+		var delta float64
+		if u < 0.5 { //nolint
+			xy := 1 - (x[j]-d.Bottom)/width
+			delta = math.Pow(2*u+(1-2*u)*math.Pow(xy, m.Eta+1), power) - 1
+		} else {
+			xy := 1 - (d.Top-x[j])/width
+			delta = 1 - math.Pow(2*(1-u)+2*(u-0.5)*math.Pow(xy, m.Eta+1), power) //nolint
+		}
+
+		x[j] = d.Normalize(x[j] + delta*width)
+	}
+}
+
+// GAGaussianMutation гауссова мутация, Sigma - стандартное отклонение в доле ширины области определения.
+// Rate - вероятность мутации переменной, 0 - 1/размерность.
+type GAGaussianMutation struct {
+	Sigma float64
+	Rate  float64
+}
+
+func (m GAGaussianMutation) Validate() error {
+	if !(m.Sigma >= 0) {
+		return fmt.Errorf("%w: Sigma %v", ErrConfigNegative, m.Sigma)
+	}
+
+	if !(0 <= m.Rate && m.Rate <= 1) {
+		return fmt.Errorf("%w: Rate %v", ErrConfigProbability, m.Rate)
+	}
+
+	return nil
+}
+
+func (m GAGaussianMutation) Mutate(rnd *random.Generator, fd functions.FuncDomain, x []float64) {
+	rate := mutationRate(m.Rate, len(x))
+
+	for j := range x {
+		if rnd.Float64() >= rate {
+			continue
+		}
+
+		d := fd.VarDomain(j)
+		x[j] = d.Normalize(x[j] + rnd.NormalStep(m.Sigma*(d.Top-d.Bottom)))
+	}
+}
+
+func mutationRate(rate float64, dim int) float64 {
+	if rate == 0 {
+		return 1 / float64(dim)
+	}
+
+	return rate
+}
+
+// GAConfig настройки генетического алгоритма.
+type GAConfig struct {
+	FD             functions.FuncDomain
+	PopulationSize int
+	Selection      GASelection
+	Crossover      GACrossover
+	CrossoverProb  float64 // вероятность скрещивания пары родителей, иначе потомки - их копии.
+	Mutation       GAMutation
+	Elitism        int    // количество лучших особей, переходящих в следующее поколение без изменений.
+	Seed           uint64 // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultGAConfig() *GAConfig {
+	return &GAConfig{
+		FD:             DefaultHSFD,
+		PopulationSize: DefaultGAPopulationSize,
+		Selection:      GATournament{Size: DefaultGATournamentSize},
+		Crossover:      GASBX{Eta: DefaultGASBXEta},
+		CrossoverProb:  DefaultGACrossoverProb,
+		Mutation:       GAPolynomialMutation{Eta: DefaultGAPolynomialEta},
+		Elitism:        DefaultGAElitism,
+	}
+}
+
+func (c *GAConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.PopulationSize < 2 { //nolint
+		return fmt.Errorf("%w: PopulationSize %d", ErrConfigPopulationSize, c.PopulationSize)
+	}
+
+	if c.Elitism < 0 || c.Elitism >= c.PopulationSize {
+		return fmt.Errorf("%w: %d", ErrGAElitism, c.Elitism)
+	}
+
+	if !(0 <= c.CrossoverProb && c.CrossoverProb <= 1) {
+		return fmt.Errorf("%w: CrossoverProb %v", ErrConfigProbability, c.CrossoverProb)
+	}
+
+	operators := []struct {
+		name string
+		op   interface{}
+	}{
+		{name: "Selection", op: c.Selection},
+		{name: "Crossover", op: c.Crossover},
+		{name: "Mutation", op: c.Mutation},
+	}
+
+	for _, o := range operators {
+		if o.op == nil {
+			return fmt.Errorf("%w: %s", ErrGAOperator, o.name)
+		}
+
+		if v, ok := o.op.(validator); ok {
+			if err := v.Validate(); err != nil {
+				return fmt.Errorf("%s: %w", o.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*GA)(nil)
+
+// GA вещественный генетический алгоритм (Genetic Algorithm).
+//
+// Потомки поколения вычисляются одновременно в optimize.Settings.Concurrent задачах,
+// лучшие Elitism особей переходят в следующее поколение без повторного вычисления.
+type GA struct {
+	populationMethod
+	conf  *GAConfig
+	state *gaState
+}
+
+type gaState struct {
+	dim int
+	rnd *random.Generator
+
+	pop       [][]float64 // популяция.
+	popF      []float64   // значения популяции.
+	offspring [][]float64 // потомки поколения.
+
+	initialized bool // вычислена ли начальная популяция.
+}
+
+// NewGA создать экземпляр генетического алгоритма. Настройки conf, по умолчанию DefaultGAConfig, копируются.
+func NewGA(conf *GAConfig) (*GA, error) {
+	if conf == nil {
+		conf = DefaultGAConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &GA{conf: &c}, nil
+}
+
+func MustGA(conf *GAConfig) *GA {
+	g, err := NewGA(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return g
+}
+
+func (g *GA) Init(dim, tasks int) int {
+	c := g.conf
+	s := &gaState{
+		dim:       dim,
+		rnd:       random.NewGenerator(c.Seed),
+		pop:       make([][]float64, c.PopulationSize),
+		popF:      make([]float64, c.PopulationSize),
+		offspring: make([][]float64, c.PopulationSize-c.Elitism),
+	}
+
+	for i := range s.offspring {
+		s.offspring[i] = make([]float64, dim)
+	}
+
+	g.state = s
+
+	return g.init(g, tasks)
+}
+
+func (g *GA) start(x []float64) {
+	s := g.state
+
+	for i := range s.pop {
+		if i == 0 {
+			s.pop[i] = normalizePoint(x, g.conf.FD)
+		} else {
+			s.pop[i] = randomPoint(s.rnd, s.dim, g.conf.FD)
+		}
+	}
+
+	s.initialized = false
+}
+
+func (g *GA) ask() [][]float64 {
+	s := g.state
+	c := g.conf
+
+	if !s.initialized {
+		return s.pop
+	}
+
+	n := len(s.offspring)
+	parents := c.Selection.Select(s.rnd, s.popF, n+n%2)
+
+	for i := 0; i < n; i += 2 {
+		p1, p2 := s.pop[parents[i]], s.pop[parents[i+1]]
+		c1 := s.offspring[i]
+
+		// при нечетном количестве второй потомок последней пары отбрасывается.
+		c2 := make([]float64, s.dim)
+		if i+1 < n {
+			c2 = s.offspring[i+1]
+		}
+
+		if s.rnd.Float64() < c.CrossoverProb {
+			c.Crossover.Crossover(s.rnd, c.FD, p1, p2, c1, c2)
+		} else {
+			copy(c1, p1)
+			copy(c2, p2)
+		}
+
+		c.Mutation.Mutate(s.rnd, c.FD, c1)
+		c.Mutation.Mutate(s.rnd, c.FD, c2)
+	}
+
+	return s.offspring
+}
+
+func (g *GA) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := g.state
+
+	if !s.initialized {
+		copy(s.popF, fs)
+		s.initialized = true
+
+		return optimize.NotTerminated, nil
+	}
+
+	// элита занимает начало популяции, остальное - потомки.
+	order := argsort(s.popF)
+	pop := make([][]float64, 0, len(s.pop))
+	popF := make([]float64, 0, len(s.pop))
+
+	for _, i := range order[:g.conf.Elitism] {
+		pop = append(pop, s.pop[i])
+		popF = append(popF, s.popF[i])
+	}
+
+	for i, x := range xs {
+		pop = append(pop, append([]float64(nil), x...))
+		popF = append(popF, fs[i])
+	}
+
+	s.pop, s.popF = pop, popF
+
+	return optimize.NotTerminated, nil
+}
@@ -0,0 +1,170 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestGA_Run(t *testing.T) {
+	selections := []struct {
+		name      string
+		selection internaloptimize.GASelection
+	}{
+		{name: "Tournament", selection: internaloptimize.GATournament{Size: 2}},
+		{name: "Roulette", selection: internaloptimize.GARoulette{}},
+		{name: "Rank", selection: internaloptimize.GARank{Pressure: 1.8}},
+	}
+	crossovers := []struct {
+		name      string
+		crossover internaloptimize.GACrossover
+	}{
+		{name: "SBX", crossover: internaloptimize.GASBX{Eta: 20}},
+		{name: "BLXAlpha", crossover: internaloptimize.GABLXAlpha{Alpha: 0.5}},
+		{name: "Arithmetic", crossover: internaloptimize.GAArithmetic{}},
+	}
+	mutations := []struct {
+		name     string
+		mutation internaloptimize.GAMutation
+	}{
+		{name: "Polynomial", mutation: internaloptimize.GAPolynomialMutation{Eta: 20}},
+		{name: "Gaussian", mutation: internaloptimize.GAGaussianMutation{Sigma: 0.05}},
+	}
+
+	for _, selection := range selections {
+		for _, crossover := range crossovers {
+			for _, mutation := range mutations {
+				t.Run(selection.name+"/"+crossover.name+"/"+mutation.name, func(t *testing.T) {
+					asserting := assert.New(t)
+
+					d := functions.VarDomain{Bottom: -10, Top: 10}
+					conf := internaloptimize.DefaultGAConfig()
+					conf.FD = functions.NewSingleFuncDomain(d)
+					conf.Selection = selection.selection
+					conf.Crossover = crossover.crossover
+					conf.Mutation = mutation.mutation
+					conf.Seed = 3
+
+					prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+					settings := &optimize.Settings{
+						Converger:       optimize.NeverTerminate{},
+						FuncEvaluations: 10_000,
+						Concurrent:      4,
+					}
+
+					result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustGA(conf))
+					asserting.NoError(err)
+					asserting.InDelta(0, result.F, 0.05)
+
+					for _, v := range result.X {
+						asserting.NoError(d.Validate(v))
+					}
+				})
+			}
+		}
+	}
+}
+
+// gaReflection пользовательская мутация: отражение точки относительно центра области определения.
+type gaReflection struct{}
+
+func (gaReflection) Mutate(rnd *random.Generator, fd functions.FuncDomain, x []float64) {
+	j := rnd.Intn(len(x))
+	d := fd.VarDomain(j)
+	x[j] = d.Top + d.Bottom - x[j]
+}
+
+func TestGA_CustomOperator(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultGAConfig()
+	conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+	conf.Mutation = gaReflection{}
+	conf.Seed = 1
+
+	prob := functions.MustProblem(functions.Matias, nil, nil)
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: 5_000,
+	}
+
+	result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustGA(conf))
+	asserting.NoError(err)
+	asserting.InDelta(0, result.F, 1e-3)
+}
+
+func TestGAConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.GAConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.GAConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.GAConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "SinglePopulation",
+			modify: func(c *internaloptimize.GAConfig) { c.PopulationSize = 1 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "ElitismFillsPopulation",
+			modify: func(c *internaloptimize.GAConfig) { c.Elitism = c.PopulationSize },
+			err:    internaloptimize.ErrGAElitism,
+		},
+		{
+			name:   "CrossoverProbAboveOne",
+			modify: func(c *internaloptimize.GAConfig) { c.CrossoverProb = 2 },
+			err:    internaloptimize.ErrConfigProbability,
+		},
+		{
+			name:   "NilSelection",
+			modify: func(c *internaloptimize.GAConfig) { c.Selection = nil },
+			err:    internaloptimize.ErrGAOperator,
+		},
+		{
+			name:   "EmptyTournament",
+			modify: func(c *internaloptimize.GAConfig) { c.Selection = internaloptimize.GATournament{} },
+			err:    internaloptimize.ErrGATournamentSize,
+		},
+		{
+			name:   "RankPressure",
+			modify: func(c *internaloptimize.GAConfig) { c.Selection = internaloptimize.GARank{Pressure: 3} },
+			err:    internaloptimize.ErrGARankPressure,
+		},
+		{
+			name:   "NegativeAlpha",
+			modify: func(c *internaloptimize.GAConfig) { c.Crossover = internaloptimize.GABLXAlpha{Alpha: -1} },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name: "MutationRate",
+			modify: func(c *internaloptimize.GAConfig) {
+				c.Mutation = internaloptimize.GAGaussianMutation{Sigma: 0.1, Rate: 1.5}
+			},
+			err: internaloptimize.ErrConfigProbability,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultGAConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewGA(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
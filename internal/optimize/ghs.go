@@ -0,0 +1,46 @@
+package optimize
+
+import (
+	"gonum.org/v1/gonum/optimize"
+)
+
+var _ optimize.Method = (*GHS)(nil)
+
+// GHS метод гармонического поиска с глобально лучшей гармонией (Global-best HarmonySearch).
+//
+// В отличие от HS подстройка не делает шаг, а копирует случайную переменную лучшей гармонии в памяти.
+type GHS struct {
+	HS
+}
+
+// NewGHS создать экземпляр метода гармонического поиска с глобально лучшей гармонией.
+func NewGHS(conf *HSConfig, opts ...HSOption) (*GHS, error) {
+	c := newHSConfig(conf, opts)
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := &GHS{}
+	g.setup(c)
+	g.adjust = g.globalBestAdjustment
+
+	return g, nil
+}
+
+func MustGHS(conf *HSConfig, opts ...HSOption) *GHS {
+	g, err := NewGHS(conf, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return g
+}
+
+// globalBestAdjustment подстройка копированием случайной переменной лучшей гармонии.
+// Значение приводится к области определения подстраиваемой переменной, у переменных она может быть разной.
+func (g *GHS) globalBestAdjustment(_ []float64, varIndex int) float64 {
+	best := g.best()
+	d := g.conf.FD.VarDomain(varIndex)
+
+	return d.Normalize(best.X[g.state.rnd.Intn(g.state.dim)])
+}
@@ -0,0 +1,109 @@
+package optimize_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestGHS_Run(t *testing.T) {
+	tests := []test{
+		{
+			enabled: true,
+			name:    "Levi13",
+			prob:    functions.MustProblem(functions.Levi13, nil, nil),
+			minimum: 0.0,
+			initX: [][]float64{
+				{10.0, 10.0}, {2.0, 7.8},
+			},
+			settings: &optimize.Settings{
+				Runtime: time.Minute,
+				Converger: &optimize.FunctionConverge{
+					Absolute:   1e-2,
+					Iterations: 100,
+				},
+			},
+			conf: &internaloptimize.HSConfig{
+				FD: functions.NewSingleFuncDomain(functions.VarDomain{
+					Bottom: -10,
+					Top:    10,
+				}),
+				MemorySize:                 internaloptimize.DefaultHSMemorySize,
+				ProbToTakeFromMemory:       internaloptimize.DefaultProbToTakeFromMemory,
+				ProbToApplyPitchAdjustment: internaloptimize.DefaultProbToApplyPitchAdjustment,
+				MaxStep:                    internaloptimize.DefaultMaxStep,
+			},
+		},
+		{
+			enabled: true,
+			name:    "Matias",
+			prob:    functions.MustProblem(functions.Matias, nil, nil),
+			minimum: 0.0,
+			initX: [][]float64{
+				{10.0, 10.0}, {2.0, 7.8},
+			},
+			settings: &optimize.Settings{
+				Runtime: time.Minute,
+				Converger: &optimize.FunctionConverge{
+					Absolute:   1e-2,
+					Iterations: 150,
+				},
+			},
+			conf: &internaloptimize.HSConfig{
+				FD: functions.NewSingleFuncDomain(functions.VarDomain{
+					Bottom: -10,
+					Top:    10,
+				}),
+				MemorySize:                 internaloptimize.DefaultHSMemorySize,
+				ProbToTakeFromMemory:       internaloptimize.DefaultProbToTakeFromMemory,
+				ProbToApplyPitchAdjustment: internaloptimize.DefaultProbToApplyPitchAdjustment,
+				MaxStep:                    internaloptimize.DefaultMaxStep,
+			},
+		},
+	}
+
+	runTests(t, tests, func(conf *internaloptimize.HSConfig) optimize.Method {
+		return internaloptimize.MustGHS(conf, internaloptimize.WithHSSeed(3))
+	})
+}
+
+func TestGHS_RunMultipleFD(t *testing.T) {
+	asserting := assert.New(t)
+
+	fd := functions.NewMultipleFuncDomain(
+		functions.VarDomain{Bottom: 0, Top: 1},
+		functions.VarDomain{Bottom: 100, Top: 200},
+	)
+
+	outside := 0
+	prob := optimize.Problem{
+		Func: func(x []float64) float64 {
+			for i, v := range x {
+				d := fd.VarDomain(i)
+				if v < d.Bottom || v > d.Top {
+					outside++
+				}
+			}
+
+			return (x[0]-0.5)*(x[0]-0.5) + (x[1]-150)*(x[1]-150)
+		},
+	}
+
+	ghs := internaloptimize.MustGHS(nil,
+		internaloptimize.WithHSFD(fd),
+		internaloptimize.WithHSProbs(0.9, 1),
+		internaloptimize.WithHSSeed(3),
+	)
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: 2000,
+	}
+
+	_, err := optimize.Minimize(prob, []float64{0.5, 150}, settings, ghs)
+	asserting.NoError(err)
+	asserting.Zero(outside)
+}
@@ -0,0 +1,241 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat"
+)
+
+const (
+	DefaultGPLengthScale = 0.3
+	DefaultGPSignal      = 1
+	DefaultGPNoise       = 1e-6
+
+	// gpHyperparameterEvaluations вычислений правдоподобия при подборе гиперпараметров.
+	gpHyperparameterEvaluations = 200
+)
+
+var (
+	ErrGPKernel              = errors.New("unknown gaussian process kernel")
+	ErrGPNoData              = errors.New("gaussian process has no observations")
+	ErrGPNotPositiveDefinite = errors.New("gaussian process covariance is not positive definite")
+)
+
+// GPKernel ковариационная функция гауссовского процесса от расстояния r между точками в длинах LengthScale.
+type GPKernel int
+
+const (
+	// GPMatern52 ядро Матерна с nu = 5/2: s*(1 + sqrt(5)*r + 5*r^2/3)*exp(-sqrt(5)*r).
+	GPMatern52 GPKernel = iota
+	// GPMatern32 ядро Матерна с nu = 3/2: s*(1 + sqrt(3)*r)*exp(-sqrt(3)*r).
+	GPMatern32
+	// GPRBF гауссовское ядро: s*exp(-r^2/2).
+	GPRBF
+)
+
+// GPHyperparameters гиперпараметры гауссовского процесса. Дисперсии относятся к стандартизованным
+// значениям функции с нулевым средним и единичным отклонением.
+type GPHyperparameters struct {
+	LengthScale float64 // масштаб расстояния между точками.
+	Signal      float64 // дисперсия сигнала s.
+	Noise       float64 // дисперсия шума наблюдений.
+}
+
+// gpBounds границы гиперпараметров при подборе по правдоподобию.
+var gpBounds = [3][2]float64{
+	{1e-3, 1e2},  //nolint
+	{1e-2, 1e2},  //nolint
+	{1e-10, 1e0}, //nolint
+}
+
+// GP регрессия гауссовским процессом с изотропным ядром.
+type GP struct {
+	Kernel GPKernel
+	Hyper  GPHyperparameters
+
+	xs    [][]float64
+	ys    []float64 // стандартизованные значения.
+	mean  float64
+	scale float64
+	chol  mat.Cholesky
+	u     *mat.TriDense // верхний треугольный множитель K = U^T * U.
+	alpha *mat.VecDense // K^-1 * ys.
+}
+
+// NewGP создать гауссовский процесс с ядром kernel и гиперпараметрами по умолчанию.
+func NewGP(kernel GPKernel) (*GP, error) {
+	if kernel < GPMatern52 || kernel > GPRBF {
+		return nil, fmt.Errorf("%w: %d", ErrGPKernel, kernel)
+	}
+
+	return &GP{
+		Kernel: kernel,
+		Hyper: GPHyperparameters{
+			LengthScale: DefaultGPLengthScale,
+			Signal:      DefaultGPSignal,
+			Noise:       DefaultGPNoise,
+		},
+	}, nil
+}
+
+// Fit обусловить процесс наблюдениями ys в точках xs при текущих гиперпараметрах.
+func (g *GP) Fit(xs [][]float64, ys []float64) error {
+	if len(xs) == 0 || len(xs) != len(ys) {
+		return ErrGPNoData
+	}
+
+	g.xs = xs
+	g.mean, g.scale = stat.MeanStdDev(ys, nil)
+
+	if !(g.scale > 0) {
+		g.scale = 1
+	}
+
+	g.ys = make([]float64, len(ys))
+	for i, y := range ys {
+		g.ys[i] = (y - g.mean) / g.scale
+	}
+
+	return g.factorize(g.Hyper)
+}
+
+// FitHyperparameters обусловить процесс наблюдениями, подобрав гиперпараметры по максимуму
+// логарифма маргинального правдоподобия методом Нелдера-Мида от текущих гиперпараметров.
+func (g *GP) FitHyperparameters(xs [][]float64, ys []float64) error {
+	if err := g.Fit(xs, ys); err != nil {
+		return err
+	}
+
+	theta := []float64{math.Log(g.Hyper.LengthScale), math.Log(g.Hyper.Signal), math.Log(g.Hyper.Noise)}
+	prob := optimize.Problem{
+		Func: func(theta []float64) float64 {
+			if err := g.factorize(gpHyper(theta)); err != nil {
+				return math.Inf(1)
+			}
+
+			return -g.LogMarginalLikelihood()
+		},
+	}
+	settings := &optimize.Settings{FuncEvaluations: gpHyperparameterEvaluations}
+
+	best := g.Hyper
+	bestLL := math.Inf(-1)
+
+	if err := g.factorize(best); err == nil {
+		bestLL = g.LogMarginalLikelihood()
+	}
+
+	// достаточно лучшей найденной точки, даже если поиск остановлен по лимиту вычислений.
+	result, _ := optimize.Minimize(prob, theta, settings, &optimize.NelderMead{})
+	if result != nil && -result.F > bestLL {
+		best = gpHyper(result.X)
+	}
+
+	g.Hyper = best
+
+	return g.factorize(g.Hyper)
+}
+
+// Predict среднее и стандартное отклонение процесса в точке x.
+func (g *GP) Predict(x []float64) (mean, std float64) {
+	if g.alpha == nil {
+		return 0, math.Sqrt(g.Hyper.Signal)
+	}
+
+	k := mat.NewVecDense(len(g.xs), nil)
+	for i, xi := range g.xs {
+		k.SetVec(i, g.kernel(g.Hyper, x, xi))
+	}
+
+	// k^T * K^-1 * k = |v|^2, U^T * v = k.
+	v := mat.VecDenseCopyOf(k)
+	blas64.Trsv(blas.Trans, g.u.RawTriangular(), v.RawVector())
+
+	variance := math.Max(0, g.Hyper.Signal-mat.Dot(v, v))
+
+	return g.mean + g.scale*mat.Dot(k, g.alpha), g.scale * math.Sqrt(variance)
+}
+
+// LogMarginalLikelihood логарифм маргинального правдоподобия стандартизованных наблюдений.
+func (g *GP) LogMarginalLikelihood() float64 {
+	if g.alpha == nil {
+		return math.Inf(-1)
+	}
+
+	y := mat.NewVecDense(len(g.ys), g.ys)
+
+	return -0.5*mat.Dot(y, g.alpha) - 0.5*g.chol.LogDet() - 0.5*float64(len(g.ys))*math.Log(2*math.Pi) //nolint
+}
+
+// factorize разложение Холецкого ковариационной матрицы наблюдений при гиперпараметрах h.
+// Если матрица вырождена, на диагональ добавляется возрастающая поправка.
+func (g *GP) factorize(h GPHyperparameters) error {
+	n := len(g.xs)
+	k := mat.NewSymDense(n, nil)
+
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			k.SetSym(i, j, g.kernel(h, g.xs[i], g.xs[j]))
+		}
+	}
+
+	g.alpha = nil
+
+	for jitter := 0.0; jitter <= 1e-4; jitter = math.Max(1e-10, jitter*100) { //nolint
+		for i := 0; i < n; i++ {
+			k.SetSym(i, i, h.Signal+h.Noise+jitter)
+		}
+
+		if !g.chol.Factorize(k) {
+			continue
+		}
+
+		alpha := mat.NewVecDense(n, nil)
+		if err := g.chol.SolveVecTo(alpha, mat.NewVecDense(n, g.ys)); err != nil {
+			continue
+		}
+
+		g.u = mat.NewTriDense(n, mat.Upper, nil)
+		g.chol.UTo(g.u)
+		g.alpha = alpha
+
+		return nil
+	}
+
+	return ErrGPNotPositiveDefinite
+}
+
+// kernel ковариация точек a и b при гиперпараметрах h.
+func (g *GP) kernel(h GPHyperparameters, a, b []float64) float64 {
+	r := floats.Distance(a, b, 2) / h.LengthScale //nolint
+
+	switch g.Kernel {
+	case GPMatern32:
+		s := math.Sqrt(3) * r //nolint
+
+		return h.Signal * (1 + s) * math.Exp(-s)
+	case GPRBF:
+		return h.Signal * math.Exp(-r*r/2) //nolint
+	default:
+		s := math.Sqrt(5) * r //nolint
+
+		return h.Signal * (1 + s + s*s/3) * math.Exp(-s) //nolint
+	}
+}
+
+// gpHyper гиперпараметры из логарифмов theta, ограниченные gpBounds.
+func gpHyper(theta []float64) GPHyperparameters {
+	v := make([]float64, len(gpBounds))
+	for i, b := range gpBounds {
+		v[i] = math.Min(b[1], math.Max(b[0], math.Exp(theta[i])))
+	}
+
+	return GPHyperparameters{LengthScale: v[0], Signal: v[1], Noise: v[2]}
+}
@@ -0,0 +1,66 @@
+package optimize_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGP_Predict(t *testing.T) {
+	xs := [][]float64{{0}, {0.2}, {0.4}, {0.6}, {0.8}, {1}}
+	ys := make([]float64, len(xs))
+
+	for i, x := range xs {
+		ys[i] = math.Sin(2 * math.Pi * x[0])
+	}
+
+	for _, kernel := range []internaloptimize.GPKernel{
+		internaloptimize.GPMatern52,
+		internaloptimize.GPMatern32,
+		internaloptimize.GPRBF,
+	} {
+		asserting := assert.New(t)
+
+		gp, err := internaloptimize.NewGP(kernel)
+		asserting.NoError(err)
+
+		asserting.NoError(gp.Fit(xs, ys))
+		before := gp.LogMarginalLikelihood()
+
+		asserting.NoError(gp.FitHyperparameters(xs, ys))
+		asserting.GreaterOrEqual(gp.LogMarginalLikelihood(), before)
+
+		// в наблюдениях процесс почти без шума проходит через значения, между ними неопределенность больше.
+		for i, x := range xs {
+			mean, std := gp.Predict(x)
+			asserting.InDelta(ys[i], mean, 1e-2)
+			asserting.Less(std, 1e-1)
+		}
+
+		_, stdBetween := gp.Predict([]float64{0.1})
+		_, stdObserved := gp.Predict([]float64{0.2})
+		asserting.Greater(stdBetween, stdObserved)
+
+		_, stdFar := gp.Predict([]float64{10})
+		asserting.Greater(stdFar, stdBetween)
+	}
+}
+
+func TestGP_Errors(t *testing.T) {
+	asserting := assert.New(t)
+
+	_, err := internaloptimize.NewGP(10)
+	asserting.True(errors.Is(err, internaloptimize.ErrGPKernel), "unexpected error %v", err)
+
+	gp, err := internaloptimize.NewGP(internaloptimize.GPRBF)
+	asserting.NoError(err)
+
+	err = gp.Fit(nil, nil)
+	asserting.True(errors.Is(err, internaloptimize.ErrGPNoData), "unexpected error %v", err)
+
+	err = gp.Fit([][]float64{{0}}, []float64{1, 2})
+	asserting.True(errors.Is(err, internaloptimize.ErrGPNoData), "unexpected error %v", err)
+}
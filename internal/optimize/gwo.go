@@ -0,0 +1,181 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultGWOPopulationSize = 25
+	DefaultGWOGenerations    = 300
+)
+
+var ErrGWOGenerations = errors.New("generations must be positive")
+
+// GWOConfig настройки стаи серых волков.
+type GWOConfig struct {
+	FD             functions.FuncDomain
+	PopulationSize int
+	Generations    int    // поколений, за которые коэффициент a убывает от 2 до 0, дальше a = 0.
+	Seed           uint64 // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultGWOConfig() *GWOConfig {
+	return &GWOConfig{
+		FD:             DefaultHSFD,
+		PopulationSize: DefaultGWOPopulationSize,
+		Generations:    DefaultGWOGenerations,
+	}
+}
+
+func (c *GWOConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.PopulationSize < 3 { //nolint
+		return fmt.Errorf("%w: PopulationSize %d", ErrConfigPopulationSize, c.PopulationSize)
+	}
+
+	if c.Generations < 1 {
+		return fmt.Errorf("%w: %d", ErrGWOGenerations, c.Generations)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*GWO)(nil)
+
+// GWO метод серых волков (Grey Wolf Optimizer, Mirjalili).
+//
+// Стаю ведут три лучшие найденные точки alpha, beta и delta, каждый волк перемещается в среднее
+// положений, предложенных вожаками. Стая вычисляется одновременно в optimize.Settings.Concurrent задачах.
+type GWO struct {
+	populationMethod
+	conf  *GWOConfig
+	state *gwoState
+}
+
+type gwoState struct {
+	dim        int
+	rnd        *random.Generator
+	generation int
+
+	wolves  [][]float64         // положения волков.
+	leaders [3]*memoryComponent // alpha, beta и delta, nil - еще не выбран.
+}
+
+// NewGWO создать экземпляр метода серых волков. Настройки conf, по умолчанию DefaultGWOConfig, копируются.
+func NewGWO(conf *GWOConfig) (*GWO, error) {
+	if conf == nil {
+		conf = DefaultGWOConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &GWO{conf: &c}, nil
+}
+
+func MustGWO(conf *GWOConfig) *GWO {
+	g, err := NewGWO(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return g
+}
+
+func (g *GWO) Init(dim, tasks int) int {
+	g.state = &gwoState{
+		dim:    dim,
+		rnd:    random.NewGenerator(g.conf.Seed),
+		wolves: make([][]float64, g.conf.PopulationSize),
+	}
+
+	return g.init(g, tasks)
+}
+
+func (g *GWO) start(x []float64) {
+	s := g.state
+
+	for i := range s.wolves {
+		if i == 0 {
+			s.wolves[i] = normalizePoint(x, g.conf.FD)
+		} else {
+			s.wolves[i] = randomPoint(s.rnd, s.dim, g.conf.FD)
+		}
+	}
+
+	s.leaders = [3]*memoryComponent{}
+	s.generation = 0
+}
+
+func (g *GWO) ask() [][]float64 {
+	s := g.state
+
+	if s.generation == 0 {
+		return s.wolves
+	}
+
+	a := convergenceFactor(s.generation, g.conf.Generations)
+
+	for _, x := range s.wolves {
+		for j := range x {
+			v := 0.0
+
+			for _, leader := range s.leaders {
+				l := leader.X[j]
+				A := a * s.rnd.FloatInRange(-1, 1) //nolint
+				C := 2 * s.rnd.Float64()           //nolint
+				v += l - A*math.Abs(C*l-x[j])
+			}
+
+			d := g.conf.FD.VarDomain(j)
+			x[j] = d.Normalize(v / float64(len(s.leaders)))
+		}
+	}
+
+	return s.wolves
+}
+
+func (g *GWO) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := g.state
+
+	for i, f := range fs {
+		g.lead(xs[i], f)
+	}
+
+	s.generation++
+
+	return optimize.NotTerminated, nil
+}
+
+// lead включение точки в тройку вожаков, если она лучше одного из них или вожак еще не выбран.
+// Так вожаки выбираются из первого поколения, даже если все значения в нем бесконечны.
+func (g *GWO) lead(x []float64, f float64) {
+	leaders := &g.state.leaders
+
+	for i := range leaders {
+		if leaders[i] != nil && f >= leaders[i].F {
+			continue
+		}
+
+		copy(leaders[i+1:], leaders[i:len(leaders)-1])
+		leaders[i] = &memoryComponent{F: f, X: append([]float64(nil), x...)}
+
+		return
+	}
+}
+
+// convergenceFactor коэффициент a, линейно убывающий от 2 до 0 за generations поколений.
+func convergenceFactor(generation, generations int) float64 {
+	return 2 * math.Max(0, 1-float64(generation)/float64(generations)) //nolint
+}
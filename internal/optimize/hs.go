@@ -1,11 +1,15 @@
 package optimize
 
 import (
-	"math/rand"
+	"errors"
+	"fmt"
+	"math"
 	"sort"
+	"time"
 
 	"github.com/EmptyShadow/eltech.optimize/internal/functions"
 	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/optimize"
 )
 
@@ -15,6 +19,23 @@ const (
 	DefaultProbToTakeFromMemory       = 0.5
 	DefaultProbToApplyPitchAdjustment = 0.5
 	DefaultMaxStep                    = 1
+	DefaultHSStepFraction             = 0.05
+	DefaultHSMemoryTolerance          = 1e-10
+	DefaultHSStagnationLimit          = 10_000
+)
+
+var (
+	ErrHSMemoryCollapse = errors.New("all harmonies in memory are within tolerance")
+	ErrHSStagnation     = errors.New("best harmony has not improved for the stagnation limit")
+	ErrHSNaN            = ErrNaN
+	ErrHSSeedDimension  = errors.New("initial harmony dimension does not match the problem")
+)
+
+var (
+	// HSMemoryCollapse все гармонии в памяти совпали с точностью до HSConfig.MemoryTolerance.
+	HSMemoryCollapse = optimize.NewStatus("HSMemoryCollapse", false, ErrHSMemoryCollapse)
+	// HSStagnation лучшая гармония не улучшалась HSConfig.StagnationLimit импровизаций.
+	HSStagnation = optimize.NewStatus("HSStagnation", false, ErrHSStagnation)
 )
 
 var DefaultHSFD = functions.NewSingleFuncDomain(functions.VarDomain{
@@ -25,9 +46,20 @@ var DefaultHSFD = functions.NewSingleFuncDomain(functions.VarDomain{
 type HSConfig struct {
 	FD                         functions.FuncDomain
 	MemorySize                 int
-	ProbToTakeFromMemory       float64 // вероятность взять значение из памяти, иначе возьмем рандомное значение.
-	ProbToApplyPitchAdjustment float64 // вероятность сделать шаг, иначе возьмем из памяти.
-	MaxStep                    float64 // область определения шага.
+	ProbToTakeFromMemory       float64       // вероятность взять значение из памяти, иначе возьмем рандомное значение.
+	ProbToApplyPitchAdjustment float64       // вероятность сделать шаг, иначе возьмем из памяти.
+	MaxStep                    float64       // шаг для всех переменных, если не задан Step, 0 - шаг по FD.
+	Step                       *HSStep       // шаг для каждой переменной.
+	Replacement                HSReplacement // политика замены гармоний в памяти.
+	DuplicateTolerance         float64       // расстояние, ближе которого гармонии считаются дубликатами.
+	InitialMemory              []HSHarmony   // гармонии, которыми заполняется память до случайных.
+	MemoryTolerance            float64       // расстояние до лучшей гармонии для схлопывания памяти, 0 - не проверять.
+	StagnationLimit            int           // количество импровизаций без улучшения лучшей гармонии, 0 - не проверять.
+	Seed                       uint64        // зерно генератора случайных чисел, 0 - случайное.
+	Checkpointer               HSCheckpointer
+	CheckpointIterations       int            // контрольная точка каждые N импровизаций, 0 - не по импровизациям.
+	CheckpointPeriod           time.Duration  // контрольная точка не реже чем раз в период, 0 - не по времени.
+	LocalSearch                *HSLocalSearch // доводка лучших гармоний локальным методом, nil - без доводки.
 }
 
 func DefaultHSConfig() *HSConfig {
@@ -36,7 +68,8 @@ func DefaultHSConfig() *HSConfig {
 		MemorySize:                 DefaultHSMemorySize,
 		ProbToTakeFromMemory:       DefaultProbToTakeFromMemory,
 		ProbToApplyPitchAdjustment: DefaultProbToApplyPitchAdjustment,
-		MaxStep:                    DefaultMaxStep,
+		MemoryTolerance:            DefaultHSMemoryTolerance,
+		StagnationLimit:            DefaultHSStagnationLimit,
 	}
 }
 
@@ -45,11 +78,24 @@ var _ optimize.Method = (*HS)(nil)
 type memoryComponent struct {
 	F float64
 	X []float64
+
+	born int // номер импровизации, на которой компонент попал в память.
 }
 
 type hsState struct {
 	memory []*memoryComponent
 	dim    int
+	step   functions.FuncDomain // область определения шага подстройки.
+	rnd    *random.Generator
+
+	current []float64 // точка, от которой строятся импровизации.
+	pending []float64 // импровизация, ожидающая вычисления.
+
+	evaluations    int // количество вычислений функции.
+	improvisations int // количество вычисленных импровизаций.
+	stagnation     int // количество импровизаций без улучшения лучшей гармонии.
+
+	checkpointed time.Time // время последней контрольной точки.
 
 	status optimize.Status
 	err    error
@@ -58,89 +104,280 @@ type hsState struct {
 func newHSState(dim int, conf *HSConfig) *hsState {
 	s := &hsState{}
 
-	s.memory = make([]*memoryComponent, conf.MemorySize)
-	for i := 0; i < len(s.memory); i++ {
-		x := make([]float64, dim)
-
-		for j := 0; j < dim; j++ {
-			x[j] = random.RandValueVarInDomain(j, conf.FD)
-		}
-
-		s.memory[i] = &memoryComponent{X: x}
-	}
-
+	s.memory = make([]*memoryComponent, 0, conf.MemorySize)
 	s.dim = dim
+	s.rnd = random.NewGenerator(conf.Seed)
+	s.checkpointed = time.Now()
 	s.status = optimize.NotTerminated
-	s.err = nil
+	s.err = conf.checkDim(dim)
+
+	if s.err == nil {
+		s.step = conf.stepDomain(dim)
+	}
 
 	return s
 }
 
+// pitchAdjustment подстройка значения переменной varIndex в импровизации.
+type pitchAdjustment func(improvised []float64, varIndex int) float64
+
+// hsTuner подбор вероятностей для очередной импровизации.
+type hsTuner interface {
+	// params вероятности взять значение из памяти и сделать подстройку.
+	params(rnd *random.Generator) (probToTakeFromMemory, probToApplyPitchAdjustment float64)
+	// feedback результат последней импровизации: попала ли она в память.
+	feedback(improved bool)
+	// save сохранение состояния в контрольную точку.
+	save(cp *HSCheckpoint)
+	// load восстановление состояния из контрольной точки.
+	load(cp *HSCheckpoint)
+}
+
+// hsFixedTuner неизменные вероятности из конфигурации.
+type hsFixedTuner struct {
+	conf *HSConfig
+}
+
+func (t *hsFixedTuner) params(_ *random.Generator) (probToTakeFromMemory, probToApplyPitchAdjustment float64) {
+	return t.conf.ProbToTakeFromMemory, t.conf.ProbToApplyPitchAdjustment
+}
+
+func (t *hsFixedTuner) feedback(_ bool) {}
+
+func (t *hsFixedTuner) save(_ *HSCheckpoint) {}
+
+func (t *hsFixedTuner) load(_ *HSCheckpoint) {}
+
 // HS метод гармонического поиска (HarmonySearch).
 type HS struct {
-	conf  *HSConfig
-	state *hsState
+	conf   *HSConfig
+	state  *hsState
+	adjust pitchAdjustment
+	tuner  hsTuner
+
+	restored  *HSCheckpoint      // контрольная точка, с которой продолжится следующий запуск.
+	available optimize.Available // функции задачи, доступные для локальной доводки.
+	migration *hsMigration       // обмен гармониями с другими островами, nil - без обмена.
 }
 
 // NewHS создать экземпляр метода гармонического поиска.
-func NewHS(conf *HSConfig) *HS {
-	return &HS{
-		conf: conf,
+//
+// Настройки conf, по умолчанию DefaultHSConfig, копируются и дополняются opts.
+func NewHS(conf *HSConfig, opts ...HSOption) (*HS, error) {
+	c := newHSConfig(conf, opts)
+	if err := c.Validate(); err != nil {
+		return nil, err
 	}
+
+	h := &HS{}
+	h.setup(c)
+
+	return h, nil
+}
+
+func MustHS(conf *HSConfig, opts ...HSOption) *HS {
+	h, err := NewHS(conf, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return h
+}
+
+// setup настройка метода со стандартными подстройкой и вероятностями.
+func (h *HS) setup(conf *HSConfig) {
+	h.conf = conf
+	h.adjust = h.stepAdjustment
+	h.tuner = &hsFixedTuner{conf: conf}
 }
 
 func (h *HS) Init(dim, _ int) int {
 	h.state = newHSState(dim, h.conf)
 
+	if h.state.err == nil && h.restored != nil {
+		h.state.err = h.restore(h.restored)
+		h.restored = nil
+	}
+
 	return HSConcurrent
 }
 
 func (h *HS) Run(operation chan<- optimize.Task, result <-chan optimize.Task, tasks []optimize.Task) {
 	defer close(operation)
 
-	h.evaluateMemory(operation, result)
-	h.sortMemory()
+	if h.start(operation, result, tasks[0]) {
+		h.search(operation, result)
+	}
+
+	for range result {
+		// дочитываем результаты до закрытия канала.
+	}
+}
+
+// start заполнение памяти от стартовой задачи или продолжение с контрольной точки.
+// Вернет false, если поиск завершен.
+func (h *HS) start(operation chan<- optimize.Task, result <-chan optimize.Task, task optimize.Task) bool {
+	if h.state.err == nil && h.conf.Checkpointer != nil {
+		h.state.err = h.conf.Checkpointer.Init()
+	}
+
+	if h.state.err != nil {
+		h.state.status = optimize.Failure
+
+		methodDone(operation, task.X, task.F)
+
+		return false
+	}
+
+	if len(h.state.memory) != 0 { // продолжение с контрольной точки.
+		return majorIterationAndWait(operation, result, h.best().X, h.best().F)
+	}
+
+	start := HSHarmony{
+		X:         task.X,
+		F:         task.F,
+		Evaluated: task.Op&optimize.FuncEvaluation != 0,
+	}
+
+	seeds, err := h.seeds(start)
+	if err != nil {
+		h.state.status = optimize.Failure
+		h.state.err = err
+
+		methodDone(operation, start.X, start.F)
 
-	x := tasks[0].X
+		return false
+	}
 
-	funcEvaluation(operation, x) // Стартовое вычисление функции в стартовой точке.
+	h.state.current = seeds[0].X
 
-	for res := range result {
-		switch res.Op {
-		default:
-			panic("unknown operation")
-		case optimize.PostIteration:
-			break
-		case optimize.MajorIteration:
-			funcEvaluation(operation, res.X)
-		case optimize.FuncEvaluation: // вычисление следующей импровизации и если она лучше какой то в памяти,то супер.
-			if h.updateMemory(res.X, res.F) {
-				h.sortMemory()
+	return h.initMemory(operation, result, seeds)
+}
 
-				x = res.X
+// search импровизации от текущей точки до завершения оптимизации.
+func (h *HS) search(operation chan<- optimize.Task, result <-chan optimize.Task) {
+	for {
+		if h.state.pending == nil {
+			h.state.pending = h.improvisation(append([]float64(nil), h.state.current...))
+		}
 
-				majorIteration(operation, res.X, res.F)
+		improvised := h.state.pending
 
-				continue
+		f, ok := h.evaluate(operation, result, improvised)
+		if !ok {
+			return
+		}
+
+		h.state.pending = nil
+
+		_, done := h.improvised(improvised, f)
+		if done {
+			if h.state.status != optimize.Failure && !h.polish(operation, result) {
+				return
 			}
 
-			improvised := h.improvisation(x)
-			funcEvaluation(operation, improvised)
+			methodDone(operation, h.best().X, h.best().F)
+
+			return
+		}
+
+		if h.polishDue() && !h.polish(operation, result) {
+			return
+		}
+
+		if h.migrationDue() {
+			h.migrate()
+		}
+
+		// Лучшая гармония сообщается при ее улучшении, а при застое раз в размер памяти импровизаций,
+		// чтобы проверялись ограничения оптимизации. Замена худших гармоний не сообщается, иначе она
+		// расходовала бы окно optimize.FunctionConverge без улучшения лучшей.
+		report := h.state.stagnation%h.conf.MemorySize == 0
+		if report && !majorIterationAndWait(operation, result, h.best().X, h.best().F) {
+			return
 		}
 	}
 }
 
-func (h *HS) evaluateMemory(operation chan<- optimize.Task, result <-chan optimize.Task) {
-	// расчитываем функцию в памяти.
-	for i := 0; i < h.conf.MemorySize; i++ {
-		m := h.state.memory[i]
+// improvised обновление памяти вычисленной импровизацией x со значением f и проверка условий завершения.
+// Вернет, попала ли импровизация в память и завершен ли поиск.
+func (h *HS) improvised(x []float64, f float64) (improved, done bool) {
+	h.state.improvisations++
 
-		funcEvaluation(operation, m.X)
+	bestF := h.best().F
+	improved = h.updateMemory(x, f)
+	h.tuner.feedback(improved)
 
-		res := <-result
+	if improved {
+		h.sortMemory()
 
-		m.F = res.F
+		h.state.current = x
 	}
+
+	return improved, h.checkStatus(x, f, bestF) || !h.checkpoint()
+}
+
+// evaluate вычисление функции в точке x с учетом количества вычислений.
+func (h *HS) evaluate(operation chan<- optimize.Task, result <-chan optimize.Task, x []float64) (float64, bool) {
+	f, ok := evaluation(operation, result, x)
+	if ok {
+		h.state.evaluations++
+	}
+
+	return f, ok
+}
+
+// checkStatus проверка условий завершения после импровизации x со значением f,
+// bestF - значение лучшей гармонии до импровизации. Вернет true, если поиск завершен.
+func (h *HS) checkStatus(x []float64, f, bestF float64) bool {
+	if h.checkNaN(x, f) {
+		return true
+	}
+
+	if h.best().F < bestF {
+		h.state.stagnation = 0
+	} else {
+		h.state.stagnation++
+	}
+
+	if h.conf.StagnationLimit > 0 && h.state.stagnation >= h.conf.StagnationLimit {
+		h.state.status = HSStagnation
+
+		return true
+	}
+
+	if h.conf.MemoryTolerance > 0 && h.memoryCollapsed() {
+		h.state.status = HSMemoryCollapse
+
+		return true
+	}
+
+	return false
+}
+
+// checkNaN вернет true и переведет поиск в состояние ошибки, если значение функции NaN.
+func (h *HS) checkNaN(x []float64, f float64) bool {
+	if !math.IsNaN(f) {
+		return false
+	}
+
+	h.state.status = optimize.Failure
+	h.state.err = fmt.Errorf("%w at %v", ErrHSNaN, x)
+
+	return true
+}
+
+// memoryCollapsed все гармонии находятся в пределах HSConfig.MemoryTolerance от лучшей.
+func (h *HS) memoryCollapsed() bool {
+	best := h.best()
+
+	for _, m := range h.state.memory {
+		if floats.Distance(m.X, best.X, math.Inf(1)) > h.conf.MemoryTolerance {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (h *HS) sortMemory() {
@@ -154,50 +391,52 @@ func (h *HS) sortMemory() {
 
 func (h *HS) improvisation(improvised []float64) []float64 {
 	dim := h.state.dim
+	rnd := h.state.rnd
+	probToTakeFromMemory, probToApplyPitchAdjustment := h.tuner.params(rnd)
 
 	for varIndex := 0; varIndex < dim; varIndex++ {
-		prob1 := rand.Float64()
+		prob1 := rnd.Float64()
 
-		if prob1 >= h.conf.ProbToTakeFromMemory {
-			improvised[varIndex] = random.RandValueVarInDomain(varIndex, h.conf.FD)
+		if prob1 >= probToTakeFromMemory {
+			improvised[varIndex] = rnd.ValueVarInDomain(varIndex, h.conf.FD)
 
 			continue
 		}
 
-		prod2 := rand.Float64()
+		prod2 := rnd.Float64()
 
-		if prod2 >= h.conf.ProbToApplyPitchAdjustment {
-			m := h.state.memory[rand.Intn(h.conf.MemorySize)]
+		if prod2 >= probToApplyPitchAdjustment {
+			m := h.state.memory[rnd.Intn(h.conf.MemorySize)]
 
 			improvised[varIndex] = m.X[varIndex]
 
 			continue
 		}
 
-		d := h.conf.FD.VarDomain(varIndex)
-		step := random.RandFloatInRange(-1.0, 1.0) * h.conf.MaxStep
-		improvised[varIndex] = d.Normalize(improvised[varIndex] + step)
+		improvised[varIndex] = h.adjust(improvised, varIndex)
 	}
 
 	return improvised
 }
 
-func (h *HS) updateMemory(x []float64, f float64) bool {
-	for i := 0; i < h.conf.MemorySize; i++ {
-		if f < h.state.memory[i].F {
-			h.state.memory[i].X = x
-			h.state.memory[i].F = f
+// stepAdjustment подстройка шагом в пределах области определения шага переменной.
+func (h *HS) stepAdjustment(improvised []float64, varIndex int) float64 {
+	d := h.conf.FD.VarDomain(varIndex)
+	step := h.state.rnd.ValueVarInDomain(varIndex, h.state.step)
 
-			return true
-		}
-	}
+	return d.Normalize(improvised[varIndex] + step)
+}
 
-	return false
+// best лучшая гармония в памяти.
+func (h *HS) best() *memoryComponent {
+	return h.state.memory[len(h.state.memory)-1]
 }
 
-func (h *HS) Uses(_ optimize.Available) (uses optimize.Available, err error) {
+func (h *HS) Uses(has optimize.Available) (uses optimize.Available, err error) {
+	h.available = has
+
 	return optimize.Available{
-		Grad: false,
+		Grad: h.conf.LocalSearch != nil && has.Grad,
 		Hess: false,
 	}, nil
 }
@@ -206,6 +445,57 @@ func (h *HS) Status() (optimize.Status, error) {
 	return h.state.status, h.state.err
 }
 
+// HSStep область определения шага подстройки (bandwidth) для каждой переменной.
+//
+// Если D не задана, то шаг переменной берется в пределах Fraction от ширины ее области определения.
 type HSStep struct {
-	D functions.FuncDomain
+	D        functions.FuncDomain
+	Fraction float64
+}
+
+// NewHSStep шаг с абсолютной шириной полосы, одной для всех переменных или своей для каждой.
+func NewHSStep(bandwidths ...float64) *HSStep {
+	if len(bandwidths) == 1 {
+		return &HSStep{D: functions.NewSingleFuncDomain(*functions.NewVarDomain(-bandwidths[0], bandwidths[0]))}
+	}
+
+	ds := make([]functions.VarDomain, len(bandwidths))
+	for i, b := range bandwidths {
+		ds[i] = *functions.NewVarDomain(-b, b)
+	}
+
+	return &HSStep{D: functions.NewMultipleFuncDomain(ds...)}
+}
+
+// NewHSStepFraction шаг в доле от ширины области определения каждой переменной.
+func NewHSStepFraction(fraction float64) *HSStep {
+	return &HSStep{Fraction: fraction}
+}
+
+// domain область определения шага для переменных функции с областью определения fd.
+func (s *HSStep) domain(dim int, fd functions.FuncDomain) functions.FuncDomain {
+	if s.D != nil {
+		return s.D
+	}
+
+	ds := make([]functions.VarDomain, dim)
+	for i := range ds {
+		d := fd.VarDomain(i)
+		b := (d.Top - d.Bottom) * s.Fraction
+		ds[i] = *functions.NewVarDomain(-b, b)
+	}
+
+	return functions.NewMultipleFuncDomain(ds...)
+}
+
+// stepDomain область определения шага: HSConfig.Step, HSConfig.MaxStep или доля ширины FD.
+func (c *HSConfig) stepDomain(dim int) functions.FuncDomain {
+	switch {
+	case c.Step != nil:
+		return c.Step.domain(dim, c.FD)
+	case c.MaxStep > 0:
+		return NewHSStep(c.MaxStep).D
+	default:
+		return NewHSStepFraction(DefaultHSStepFraction).domain(dim, c.FD)
+	}
 }
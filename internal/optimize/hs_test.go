@@ -1,6 +1,7 @@
 package optimize_test
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"testing"
@@ -86,6 +87,14 @@ func TestHS_Run(t *testing.T) {
 		},
 	}
 
+	runTests(t, tests, func(conf *internaloptimize.HSConfig) optimize.Method {
+		return internaloptimize.MustHS(conf, internaloptimize.WithHSSeed(3))
+	})
+}
+
+func runTests(t *testing.T, tests []test, newMethod func(conf *internaloptimize.HSConfig) optimize.Method) {
+	t.Helper()
+
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			if !test.enabled {
@@ -96,11 +105,11 @@ func TestHS_Run(t *testing.T) {
 
 			for _, initX := range test.initX {
 				t.Run(fmt.Sprint(initX), func(t *testing.T) {
-					hs := internaloptimize.NewHS(test.conf)
+					method := newMethod(test.conf)
 
 					asserting := assert.New(t)
 
-					result, err := optimize.Minimize(test.prob, initX, test.settings, hs)
+					result, err := optimize.Minimize(test.prob, initX, test.settings, method)
 					asserting.NoError(err)
 					asserting.Contains([]optimize.Status{optimize.Success, optimize.FunctionConvergence,
 						optimize.FunctionEvaluationLimit, optimize.RuntimeLimit},
@@ -113,3 +122,130 @@ func TestHS_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestHS_Status(t *testing.T) {
+	fd := functions.NewSingleFuncDomain(functions.VarDomain{
+		Bottom: -10,
+		Top:    10,
+	})
+
+	tests := []struct {
+		name   string
+		prob   optimize.Problem
+		conf   *internaloptimize.HSConfig
+		status optimize.Status
+		err    error
+	}{
+		{
+			name: "Stagnation",
+			prob: functions.MustProblem(functions.Matias, nil, nil),
+			conf: &internaloptimize.HSConfig{
+				FD:                         fd,
+				MemorySize:                 internaloptimize.DefaultHSMemorySize,
+				ProbToTakeFromMemory:       internaloptimize.DefaultProbToTakeFromMemory,
+				ProbToApplyPitchAdjustment: internaloptimize.DefaultProbToApplyPitchAdjustment,
+				MaxStep:                    internaloptimize.DefaultMaxStep,
+				StagnationLimit:            100,
+			},
+			status: internaloptimize.HSStagnation,
+		},
+		{
+			name: "MemoryCollapse",
+			prob: functions.MustProblem(functions.Matias, nil, nil),
+			conf: &internaloptimize.HSConfig{
+				FD:                         fd,
+				MemorySize:                 5,
+				ProbToTakeFromMemory:       0.9,
+				ProbToApplyPitchAdjustment: 0.9,
+				MaxStep:                    1e-3,
+				MemoryTolerance:            1e-1,
+			},
+			status: internaloptimize.HSMemoryCollapse,
+		},
+		{
+			name: "NaN",
+			prob: optimize.Problem{
+				Func: func(x []float64) float64 {
+					if x[0] < 0 {
+						return math.NaN()
+					}
+
+					return x[0] * x[0]
+				},
+			},
+			conf: &internaloptimize.HSConfig{
+				FD:                         fd,
+				MemorySize:                 internaloptimize.DefaultHSMemorySize,
+				ProbToTakeFromMemory:       internaloptimize.DefaultProbToTakeFromMemory,
+				ProbToApplyPitchAdjustment: internaloptimize.DefaultProbToApplyPitchAdjustment,
+				MaxStep:                    internaloptimize.DefaultMaxStep,
+			},
+			status: optimize.Failure,
+			err:    internaloptimize.ErrHSNaN,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			hs := internaloptimize.MustHS(test.conf)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 1_000_000,
+			}
+
+			result, err := optimize.Minimize(test.prob, []float64{5.0, 5.0}, settings, hs)
+			asserting.Equal(test.status, result.Status)
+
+			status, statusErr := hs.Status()
+			asserting.Equal(test.status, status)
+
+			if test.err == nil {
+				asserting.NoError(err)
+				asserting.NoError(statusErr)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err))
+			asserting.True(errors.Is(statusErr, test.err))
+		})
+	}
+}
+
+func TestHS_Step(t *testing.T) {
+	fd := functions.NewMultipleFuncDomain(
+		functions.VarDomain{Bottom: -1000, Top: 1000},
+		functions.VarDomain{Bottom: -1, Top: 1},
+	)
+
+	tests := []struct {
+		name string
+		step *internaloptimize.HSStep
+	}{
+		{name: "Default"},
+		{name: "Absolute", step: internaloptimize.NewHSStep(10, 0.01)},
+		{name: "Fraction", step: internaloptimize.NewHSStepFraction(0.01)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultHSConfig()
+			conf.FD = fd
+			conf.Step = test.step
+
+			prob := functions.MustProblem("(x / 1000) ** 2 + y ** 2", nil, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 20_000,
+			}
+
+			result, err := optimize.Minimize(prob, []float64{900.0, 0.9}, settings, internaloptimize.MustHS(conf))
+			asserting.NoError(err)
+			asserting.Less(result.F, 1e-3)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package optimize
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"gonum.org/v1/gonum/optimize"
+)
+
+var (
+	ErrHSCheckpoint        = errors.New("checkpoint does not match the harmony search")
+	ErrHSCheckpointState   = errors.New("harmony search has not been started")
+	ErrHSCheckpointNotJSON = errors.New("checkpoint value is not representable in json")
+)
+
+// HSCheckpointFormat формат сериализации контрольной точки.
+type HSCheckpointFormat int
+
+const (
+	HSCheckpointJSON HSCheckpointFormat = iota
+	HSCheckpointGob
+)
+
+// HSCheckpointHarmony гармония в памяти контрольной точки.
+type HSCheckpointHarmony struct {
+	X    []float64
+	F    float64
+	Born int // номер импровизации, на которой гармония попала в память.
+}
+
+// HSTunerState состояние самонастройки вероятностей.
+type HSTunerState struct {
+	Mean           SaHSParams
+	Last           SaHSParams
+	Improvisations int
+	Successes      []SaHSParams
+	Trajectory     []SaHSParams
+}
+
+// HSCheckpoint полное состояние гармонического поиска, с которого можно продолжить оптимизацию.
+type HSCheckpoint struct {
+	Dim            int
+	Memory         []HSCheckpointHarmony // от худшей гармонии к лучшей.
+	Current        []float64             // точка, от которой строятся импровизации.
+	Pending        []float64             // импровизация, ожидающая вычисления.
+	Evaluations    int                   // количество вычислений функции.
+	Improvisations int                   // количество вычисленных импровизаций.
+	Stagnation     int                   // количество импровизаций без улучшения лучшей гармонии.
+	Rand           []byte                // состояние генератора случайных чисел.
+	Tuner          *HSTunerState         // состояние самонастройки вероятностей, если она есть.
+}
+
+// Save запись контрольной точки в формате format.
+// JSON не поддерживает бесконечные значения и NaN, для памяти с ними нужен HSCheckpointGob.
+func (cp *HSCheckpoint) Save(w io.Writer, format HSCheckpointFormat) error {
+	switch format {
+	case HSCheckpointJSON:
+		for i, m := range cp.Memory {
+			if math.IsInf(m.F, 0) || math.IsNaN(m.F) {
+				return fmt.Errorf("%w: harmony %d value %v", ErrHSCheckpointNotJSON, i, m.F)
+			}
+		}
+
+		return json.NewEncoder(w).Encode(cp)
+	case HSCheckpointGob:
+		return gob.NewEncoder(w).Encode(cp)
+	default:
+		return fmt.Errorf("unknown checkpoint format %d", format)
+	}
+}
+
+// LoadHSCheckpoint чтение контрольной точки в формате format.
+func LoadHSCheckpoint(r io.Reader, format HSCheckpointFormat) (*HSCheckpoint, error) {
+	cp := &HSCheckpoint{}
+
+	var err error
+
+	switch format {
+	case HSCheckpointJSON:
+		err = json.NewDecoder(r).Decode(cp)
+	case HSCheckpointGob:
+		err = gob.NewDecoder(r).Decode(cp)
+	default:
+		err = fmt.Errorf("unknown checkpoint format %d", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// HSCheckpointer получатель контрольных точек, по аналогии с optimize.Recorder.
+//
+// Контрольные точки создаются по HSConfig.CheckpointIterations и HSConfig.CheckpointPeriod.
+type HSCheckpointer interface {
+	Init() error
+	Record(cp *HSCheckpoint) error
+}
+
+var _ HSCheckpointer = (*HSFileCheckpointer)(nil)
+
+// HSFileCheckpointer запись последней контрольной точки в файл.
+type HSFileCheckpointer struct {
+	Path   string
+	Format HSCheckpointFormat
+}
+
+func (c *HSFileCheckpointer) Init() error {
+	return nil
+}
+
+// Record запись контрольной точки во временный файл с последующим переименованием,
+// чтобы при падении не остался недописанный файл.
+func (c *HSFileCheckpointer) Record(cp *HSCheckpoint) error {
+	tmp := c.Path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	if err = cp.Save(f, c.Format); err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, c.Path)
+}
+
+// Checkpoint контрольная точка текущего состояния поиска.
+//
+// Вызывается после завершения оптимизации, во время нее контрольные точки получает HSConfig.Checkpointer.
+func (h *HS) Checkpoint() (*HSCheckpoint, error) {
+	if h.state == nil {
+		return nil, ErrHSCheckpointState
+	}
+
+	rnd, err := h.state.rnd.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	memory := make([]HSCheckpointHarmony, len(h.state.memory))
+	for i, m := range h.state.memory {
+		memory[i] = HSCheckpointHarmony{
+			X:    append([]float64(nil), m.X...),
+			F:    m.F,
+			Born: m.born,
+		}
+	}
+
+	cp := &HSCheckpoint{
+		Dim:            h.state.dim,
+		Memory:         memory,
+		Current:        append([]float64(nil), h.state.current...),
+		Pending:        append([]float64(nil), h.state.pending...),
+		Evaluations:    h.state.evaluations,
+		Improvisations: h.state.improvisations,
+		Stagnation:     h.state.stagnation,
+		Rand:           rnd,
+	}
+	h.tuner.save(cp)
+
+	return cp, nil
+}
+
+// Restore продолжить поиск с контрольной точки при следующем запуске.
+//
+// Траектория поиска повторится, если конфигурация совпадает с той, при которой создана контрольная точка.
+// Стартовая точка оптимизации при этом игнорируется.
+func (h *HS) Restore(cp *HSCheckpoint) {
+	h.restored = cp
+}
+
+// restore восстановление состояния из контрольной точки.
+func (h *HS) restore(cp *HSCheckpoint) error {
+	if cp.Dim != h.state.dim {
+		return fmt.Errorf("%w: dimension %d, expected %d", ErrHSCheckpoint, cp.Dim, h.state.dim)
+	}
+
+	if len(cp.Memory) != h.conf.MemorySize {
+		return fmt.Errorf("%w: memory size %d, expected %d", ErrHSCheckpoint, len(cp.Memory), h.conf.MemorySize)
+	}
+
+	if len(cp.Current) != cp.Dim {
+		return fmt.Errorf("%w: current dimension %d, expected %d", ErrHSCheckpoint, len(cp.Current), cp.Dim)
+	}
+
+	if len(cp.Pending) != 0 && len(cp.Pending) != cp.Dim {
+		return fmt.Errorf("%w: pending dimension %d, expected %d", ErrHSCheckpoint, len(cp.Pending), cp.Dim)
+	}
+
+	for i, m := range cp.Memory {
+		if len(m.X) != cp.Dim {
+			return fmt.Errorf("%w: harmony %d dimension %d, expected %d", ErrHSCheckpoint, i, len(m.X), cp.Dim)
+		}
+	}
+
+	if err := h.state.rnd.UnmarshalBinary(cp.Rand); err != nil {
+		return err
+	}
+
+	memory := make([]*memoryComponent, len(cp.Memory))
+	for i, m := range cp.Memory {
+		memory[i] = &memoryComponent{
+			X:    append([]float64(nil), m.X...),
+			F:    m.F,
+			born: m.Born,
+		}
+	}
+
+	h.state.memory = memory
+	h.state.current = append([]float64(nil), cp.Current...)
+	h.state.evaluations = cp.Evaluations
+	h.state.improvisations = cp.Improvisations
+	h.state.stagnation = cp.Stagnation
+
+	if len(cp.Pending) != 0 {
+		h.state.pending = append([]float64(nil), cp.Pending...)
+	}
+
+	h.tuner.load(cp)
+
+	return nil
+}
+
+// checkpoint передача контрольной точки HSConfig.Checkpointer, если пора.
+// Вернет false, если не удалось ее создать или записать.
+func (h *HS) checkpoint() bool {
+	c := h.conf
+
+	if c.Checkpointer == nil {
+		return true
+	}
+
+	byIterations := c.CheckpointIterations > 0 && h.state.improvisations%c.CheckpointIterations == 0
+	byPeriod := c.CheckpointPeriod > 0 && time.Since(h.state.checkpointed) >= c.CheckpointPeriod
+
+	if !byIterations && !byPeriod {
+		return true
+	}
+
+	cp, err := h.Checkpoint()
+	if err == nil {
+		err = c.Checkpointer.Record(cp)
+	}
+
+	if err != nil {
+		h.state.status = optimize.Failure
+		h.state.err = err
+
+		return false
+	}
+
+	h.state.checkpointed = time.Now()
+
+	return true
+}
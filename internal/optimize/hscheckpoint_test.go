@@ -0,0 +1,209 @@
+package optimize_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+type checkpointMethod interface {
+	optimize.Method
+	Memory() []internaloptimize.HSHarmony
+	Checkpoint() (*internaloptimize.HSCheckpoint, error)
+	Restore(cp *internaloptimize.HSCheckpoint)
+}
+
+func TestHS_Checkpoint(t *testing.T) {
+	const evaluations = 600
+
+	tests := []struct {
+		name      string
+		format    internaloptimize.HSCheckpointFormat
+		newMethod func() checkpointMethod
+	}{
+		{
+			name:   "HS_JSON",
+			format: internaloptimize.HSCheckpointJSON,
+			newMethod: func() checkpointMethod {
+				conf := internaloptimize.DefaultHSConfig()
+				conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+				conf.MemorySize = 10
+				conf.Seed = 42
+
+				return internaloptimize.MustHS(conf)
+			},
+		},
+		{
+			name:   "SaHS_Gob",
+			format: internaloptimize.HSCheckpointGob,
+			newMethod: func() checkpointMethod {
+				conf := internaloptimize.DefaultSaHSConfig()
+				conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+				conf.MemorySize = 10
+				conf.LearningPeriod = 50
+				conf.Seed = 42
+
+				return internaloptimize.MustSaHS(conf)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+			initX := []float64{5.0, 5.0}
+			settings := func(evaluations int) *optimize.Settings {
+				return &optimize.Settings{
+					Converger:       optimize.NeverTerminate{},
+					FuncEvaluations: evaluations,
+				}
+			}
+
+			whole := test.newMethod()
+			_, err := optimize.Minimize(prob, initX, settings(evaluations), whole)
+			asserting.NoError(err)
+
+			first := test.newMethod()
+			_, err = optimize.Minimize(prob, initX, settings(evaluations/2), first)
+			asserting.NoError(err)
+
+			cp, err := first.Checkpoint()
+			asserting.NoError(err)
+
+			buf := &bytes.Buffer{}
+			asserting.NoError(cp.Save(buf, test.format))
+
+			loaded, err := internaloptimize.LoadHSCheckpoint(buf, test.format)
+			asserting.NoError(err)
+
+			// последнее вычисление первого запуска отбрасывается при остановке, продолжение его повторит.
+			second := test.newMethod()
+			second.Restore(loaded)
+			_, err = optimize.Minimize(prob, initX, settings(evaluations/2+1), second)
+			asserting.NoError(err)
+
+			asserting.Equal(whole.Memory(), second.Memory(), "resumed search must repeat the trajectory")
+		})
+	}
+}
+
+func TestHS_CheckpointMismatch(t *testing.T) {
+	newHS := func() *internaloptimize.HS {
+		conf := internaloptimize.DefaultHSConfig()
+		conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+		conf.MemorySize = 10
+		conf.Seed = 42
+
+		return internaloptimize.MustHS(conf)
+	}
+
+	prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+	settings := func() *optimize.Settings { return &optimize.Settings{FuncEvaluations: 100} }
+
+	tests := []struct {
+		name   string
+		modify func(cp *internaloptimize.HSCheckpoint)
+	}{
+		{name: "Dimension", modify: func(cp *internaloptimize.HSCheckpoint) { cp.Dim = 3 }},
+		{name: "MemorySize", modify: func(cp *internaloptimize.HSCheckpoint) { cp.Memory = cp.Memory[1:] }},
+		{name: "Current", modify: func(cp *internaloptimize.HSCheckpoint) { cp.Current = cp.Current[:1] }},
+		{name: "Pending", modify: func(cp *internaloptimize.HSCheckpoint) { cp.Pending = []float64{1, 2, 3} }},
+		{name: "Harmony", modify: func(cp *internaloptimize.HSCheckpoint) { cp.Memory[3].X = cp.Memory[3].X[:1] }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			first := newHS()
+			_, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings(), first)
+			asserting.NoError(err)
+
+			cp, err := first.Checkpoint()
+			asserting.NoError(err)
+			test.modify(cp)
+
+			hs := newHS()
+			hs.Restore(cp)
+
+			result, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings(), hs)
+			asserting.True(errors.Is(err, internaloptimize.ErrHSCheckpoint), "unexpected error %v", err)
+			asserting.Equal(optimize.Failure, result.Status)
+		})
+	}
+}
+
+func TestHS_CheckpointErrors(t *testing.T) {
+	asserting := assert.New(t)
+
+	hs := internaloptimize.MustHS(nil, internaloptimize.WithHSMemorySize(10))
+
+	_, err := hs.Checkpoint()
+	asserting.True(errors.Is(err, internaloptimize.ErrHSCheckpointState), "unexpected error %v", err)
+
+	inf := func(x []float64) float64 { return math.Inf(1) }
+	prob := optimize.Problem{Func: inf}
+	settings := &optimize.Settings{FuncEvaluations: 20}
+
+	_, err = optimize.Minimize(prob, []float64{5.0, 5.0}, settings, hs)
+	asserting.NoError(err)
+
+	cp, err := hs.Checkpoint()
+	asserting.NoError(err)
+
+	err = cp.Save(&bytes.Buffer{}, internaloptimize.HSCheckpointJSON)
+	asserting.True(errors.Is(err, internaloptimize.ErrHSCheckpointNotJSON), "unexpected error %v", err)
+
+	buf := &bytes.Buffer{}
+	asserting.NoError(cp.Save(buf, internaloptimize.HSCheckpointGob))
+
+	loaded, err := internaloptimize.LoadHSCheckpoint(buf, internaloptimize.HSCheckpointGob)
+	asserting.NoError(err)
+	asserting.True(math.IsInf(loaded.Memory[0].F, 1))
+}
+
+func TestHSFileCheckpointer(t *testing.T) {
+	const iterations = 50
+
+	asserting := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "hs.checkpoint")
+
+	conf := internaloptimize.DefaultHSConfig()
+	conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+	conf.MemorySize = 10
+	conf.Checkpointer = &internaloptimize.HSFileCheckpointer{Path: path, Format: internaloptimize.HSCheckpointJSON}
+	conf.CheckpointIterations = iterations
+
+	prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: 500,
+	}
+
+	_, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings, internaloptimize.MustHS(conf))
+	asserting.NoError(err)
+
+	f, err := os.Open(path)
+	if !asserting.NoError(err) {
+		return
+	}
+	defer f.Close()
+
+	cp, err := internaloptimize.LoadHSCheckpoint(f, internaloptimize.HSCheckpointJSON)
+	asserting.NoError(err)
+	asserting.Equal(2, cp.Dim)
+	asserting.Len(cp.Memory, conf.MemorySize)
+	asserting.NotZero(cp.Improvisations)
+	asserting.Zero(cp.Improvisations % iterations)
+}
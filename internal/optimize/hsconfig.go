@@ -0,0 +1,391 @@
+package optimize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+)
+
+var (
+	ErrHSConfigFD          = ErrConfigFD
+	ErrHSConfigMemorySize  = errors.New("memory size must be positive")
+	ErrHSConfigProbability = ErrConfigProbability
+	ErrHSConfigNegative    = ErrConfigNegative
+	ErrHSConfigStep        = errors.New("step must have a domain or a positive fraction")
+	ErrHSConfigReplacement = errors.New("unknown memory replacement policy")
+	ErrHSConfigLocalSearch = errors.New("local search budget must be positive")
+	ErrHSConfigDomainJSON  = errors.New("function domain can not be saved")
+	ErrHSConfigDomainKind  = errors.New("unknown function domain kind")
+	ErrHSConfigDomain      = errors.New("variable domain bottom must not exceed top")
+	ErrHSConfigDomainDim   = errors.New("function domain does not match the problem dimension")
+)
+
+// Validate проверка настроек, которые иначе приведут к панике или ошибке во время поиска.
+func (c *HSConfig) Validate() error {
+	if c.FD == nil {
+		return ErrHSConfigFD
+	}
+
+	if err := validateFuncDomain("FD", c.FD); err != nil {
+		return err
+	}
+
+	if c.MemorySize <= 0 {
+		return fmt.Errorf("%w: MemorySize %d", ErrHSConfigMemorySize, c.MemorySize)
+	}
+
+	probs := []struct {
+		name string
+		p    float64
+	}{
+		{name: "ProbToTakeFromMemory", p: c.ProbToTakeFromMemory},
+		{name: "ProbToApplyPitchAdjustment", p: c.ProbToApplyPitchAdjustment},
+	}
+
+	for _, prob := range probs {
+		if !(0 <= prob.p && prob.p <= 1) {
+			return fmt.Errorf("%w: %s %v", ErrHSConfigProbability, prob.name, prob.p)
+		}
+	}
+
+	nonNegative := []struct {
+		name string
+		v    float64
+	}{
+		{name: "MaxStep", v: c.MaxStep},
+		{name: "DuplicateTolerance", v: c.DuplicateTolerance},
+		{name: "MemoryTolerance", v: c.MemoryTolerance},
+		{name: "StagnationLimit", v: float64(c.StagnationLimit)},
+		{name: "CheckpointIterations", v: float64(c.CheckpointIterations)},
+		{name: "CheckpointPeriod", v: float64(c.CheckpointPeriod)},
+	}
+
+	for _, v := range nonNegative {
+		if !(v.v >= 0) {
+			return fmt.Errorf("%w: %s %v", ErrHSConfigNegative, v.name, v.v)
+		}
+	}
+
+	if c.Step != nil && c.Step.D == nil && !(c.Step.Fraction > 0) {
+		return fmt.Errorf("%w: Fraction %v", ErrHSConfigStep, c.Step.Fraction)
+	}
+
+	if c.Step != nil {
+		if err := validateFuncDomain("Step.D", c.Step.D); err != nil {
+			return err
+		}
+	}
+
+	if c.Replacement < HSReplaceWorst || c.Replacement > HSReplaceOldest {
+		return fmt.Errorf("%w: %d", ErrHSConfigReplacement, c.Replacement)
+	}
+
+	if c.LocalSearch != nil {
+		if c.LocalSearch.FuncEvaluations <= 0 {
+			return fmt.Errorf("%w: FuncEvaluations %d", ErrHSConfigLocalSearch, c.LocalSearch.FuncEvaluations)
+		}
+
+		if c.LocalSearch.Period < 0 {
+			return fmt.Errorf("%w: LocalSearch.Period %d", ErrHSConfigNegative, c.LocalSearch.Period)
+		}
+	}
+
+	return nil
+}
+
+// checkDim проверка, что области определения переменных подходят задаче размерности dim.
+func (c *HSConfig) checkDim(dim int) error {
+	if err := checkFuncDomainDim("FD", c.FD, dim); err != nil {
+		return err
+	}
+
+	if c.Step != nil {
+		return checkFuncDomainDim("Step.D", c.Step.D, dim)
+	}
+
+	return nil
+}
+
+// validateFuncDomain проверка границ областей переменных fd с именем name,
+// если они известны без размерности задачи.
+func validateFuncDomain(name string, fd functions.FuncDomain) error {
+	var ds []functions.VarDomain
+
+	switch d := fd.(type) {
+	case *functions.SingleFuncDomain:
+		ds = []functions.VarDomain{d.VarDomain(0)}
+	case *functions.MultipleFuncDomain:
+		ds = d.VarDomains()
+	}
+
+	for i, d := range ds {
+		if !(d.Bottom <= d.Top) {
+			return fmt.Errorf("%w: %s variable %d [%v, %v]", ErrHSConfigDomain, name, i, d.Bottom, d.Top)
+		}
+	}
+
+	return nil
+}
+
+// checkFuncDomainDim проверка, что у каждой из dim переменных есть своя область в fd с именем name.
+func checkFuncDomainDim(name string, fd functions.FuncDomain, dim int) error {
+	if d, ok := fd.(*functions.MultipleFuncDomain); ok && len(d.VarDomains()) != dim {
+		return fmt.Errorf("%w: %s has %d variable domains, dimension %d",
+			ErrHSConfigDomainDim, name, len(d.VarDomains()), dim)
+	}
+
+	return nil
+}
+
+// HSOption изменение настроек гармонического поиска.
+type HSOption func(c *HSConfig)
+
+func WithHSFD(fd functions.FuncDomain) HSOption {
+	return func(c *HSConfig) {
+		c.FD = fd
+	}
+}
+
+func WithHSMemorySize(size int) HSOption {
+	return func(c *HSConfig) {
+		c.MemorySize = size
+	}
+}
+
+// WithHSProbs вероятности взять значение из памяти и сделать подстройку.
+func WithHSProbs(probToTakeFromMemory, probToApplyPitchAdjustment float64) HSOption {
+	return func(c *HSConfig) {
+		c.ProbToTakeFromMemory = probToTakeFromMemory
+		c.ProbToApplyPitchAdjustment = probToApplyPitchAdjustment
+	}
+}
+
+func WithHSStep(step *HSStep) HSOption {
+	return func(c *HSConfig) {
+		c.Step = step
+	}
+}
+
+func WithHSReplacement(replacement HSReplacement, duplicateTolerance float64) HSOption {
+	return func(c *HSConfig) {
+		c.Replacement = replacement
+		c.DuplicateTolerance = duplicateTolerance
+	}
+}
+
+func WithHSInitialMemory(harmonies ...HSHarmony) HSOption {
+	return func(c *HSConfig) {
+		c.InitialMemory = harmonies
+	}
+}
+
+// WithHSTermination условия завершения поиска, 0 - не проверять.
+func WithHSTermination(memoryTolerance float64, stagnationLimit int) HSOption {
+	return func(c *HSConfig) {
+		c.MemoryTolerance = memoryTolerance
+		c.StagnationLimit = stagnationLimit
+	}
+}
+
+func WithHSSeed(seed uint64) HSOption {
+	return func(c *HSConfig) {
+		c.Seed = seed
+	}
+}
+
+func WithHSCheckpointer(checkpointer HSCheckpointer, iterations int, period time.Duration) HSOption {
+	return func(c *HSConfig) {
+		c.Checkpointer = checkpointer
+		c.CheckpointIterations = iterations
+		c.CheckpointPeriod = period
+	}
+}
+
+func WithHSLocalSearch(localSearch *HSLocalSearch) HSOption {
+	return func(c *HSConfig) {
+		c.LocalSearch = localSearch
+	}
+}
+
+// newHSConfig копия conf, по умолчанию DefaultHSConfig, с примененными opts.
+func newHSConfig(conf *HSConfig, opts []HSOption) *HSConfig {
+	if conf == nil {
+		conf = DefaultHSConfig()
+	}
+
+	c := *conf
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &c
+}
+
+// Виды сериализуемых областей определения.
+const (
+	funcDomainSingle   = "single"   // functions.SingleFuncDomain.
+	funcDomainMultiple = "multiple" // functions.MultipleFuncDomain.
+)
+
+// funcDomainJSON сериализуемая область определения с явным видом, чтобы MultipleFuncDomain
+// одной переменной не читалась как SingleFuncDomain.
+type funcDomainJSON struct {
+	Kind    string
+	Domains []functions.VarDomain
+}
+
+// hsStepJSON сериализуемый шаг подстройки.
+type hsStepJSON struct {
+	D        *funcDomainJSON `json:",omitempty"`
+	Fraction float64         `json:",omitempty"`
+}
+
+// hsConfigJSON сериализуемые настройки гармонического поиска.
+// HSConfig.Checkpointer и HSLocalSearch.Method не сохраняются.
+type hsConfigJSON struct {
+	FD                         *funcDomainJSON
+	MemorySize                 int
+	ProbToTakeFromMemory       float64
+	ProbToApplyPitchAdjustment float64
+	MaxStep                    float64
+	Step                       *hsStepJSON `json:",omitempty"`
+	Replacement                HSReplacement
+	DuplicateTolerance         float64
+	InitialMemory              []HSHarmony `json:",omitempty"`
+	MemoryTolerance            float64
+	StagnationLimit            int
+	Seed                       uint64
+	CheckpointIterations       int
+	CheckpointPeriod           time.Duration
+	LocalSearch                *HSLocalSearch `json:",omitempty"`
+}
+
+// Save запись настроек в JSON.
+func (c *HSConfig) Save(w io.Writer) error {
+	fd, err := newFuncDomainJSON(c.FD)
+	if err != nil {
+		return err
+	}
+
+	dto := &hsConfigJSON{
+		FD:                         fd,
+		MemorySize:                 c.MemorySize,
+		ProbToTakeFromMemory:       c.ProbToTakeFromMemory,
+		ProbToApplyPitchAdjustment: c.ProbToApplyPitchAdjustment,
+		MaxStep:                    c.MaxStep,
+		Replacement:                c.Replacement,
+		DuplicateTolerance:         c.DuplicateTolerance,
+		InitialMemory:              c.InitialMemory,
+		MemoryTolerance:            c.MemoryTolerance,
+		StagnationLimit:            c.StagnationLimit,
+		Seed:                       c.Seed,
+		CheckpointIterations:       c.CheckpointIterations,
+		CheckpointPeriod:           c.CheckpointPeriod,
+		LocalSearch:                c.LocalSearch,
+	}
+
+	if c.Step != nil {
+		d, err := newFuncDomainJSON(c.Step.D)
+		if err != nil {
+			return err
+		}
+
+		dto.Step = &hsStepJSON{D: d, Fraction: c.Step.Fraction}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+
+	return enc.Encode(dto)
+}
+
+// LoadHSConfig чтение настроек из JSON. Отсутствующие поля берутся из DefaultHSConfig.
+func LoadHSConfig(r io.Reader) (*HSConfig, error) {
+	def := DefaultHSConfig()
+	dto := &hsConfigJSON{
+		MemorySize:                 def.MemorySize,
+		ProbToTakeFromMemory:       def.ProbToTakeFromMemory,
+		ProbToApplyPitchAdjustment: def.ProbToApplyPitchAdjustment,
+		MemoryTolerance:            def.MemoryTolerance,
+		StagnationLimit:            def.StagnationLimit,
+	}
+
+	if err := json.NewDecoder(r).Decode(dto); err != nil {
+		return nil, err
+	}
+
+	fd, err := dto.FD.funcDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.FD == nil {
+		fd = def.FD
+	}
+
+	conf := &HSConfig{
+		FD:                         fd,
+		MemorySize:                 dto.MemorySize,
+		ProbToTakeFromMemory:       dto.ProbToTakeFromMemory,
+		ProbToApplyPitchAdjustment: dto.ProbToApplyPitchAdjustment,
+		MaxStep:                    dto.MaxStep,
+		Replacement:                dto.Replacement,
+		DuplicateTolerance:         dto.DuplicateTolerance,
+		InitialMemory:              dto.InitialMemory,
+		MemoryTolerance:            dto.MemoryTolerance,
+		StagnationLimit:            dto.StagnationLimit,
+		Seed:                       dto.Seed,
+		CheckpointIterations:       dto.CheckpointIterations,
+		CheckpointPeriod:           dto.CheckpointPeriod,
+		LocalSearch:                dto.LocalSearch,
+	}
+
+	if dto.Step != nil {
+		d, err := dto.Step.D.funcDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		conf.Step = &HSStep{D: d, Fraction: dto.Step.Fraction}
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// newFuncDomainJSON область определения fd для сериализации, nil - не задана.
+func newFuncDomainJSON(fd functions.FuncDomain) (*funcDomainJSON, error) {
+	switch d := fd.(type) {
+	case nil:
+		return nil, nil
+	case *functions.SingleFuncDomain:
+		return &funcDomainJSON{Kind: funcDomainSingle, Domains: []functions.VarDomain{d.VarDomain(0)}}, nil
+	case *functions.MultipleFuncDomain:
+		return &funcDomainJSON{Kind: funcDomainMultiple, Domains: d.VarDomains()}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrHSConfigDomainJSON, fd)
+	}
+}
+
+// funcDomain область определения из сериализованной, nil - не задана.
+func (d *funcDomainJSON) funcDomain() (functions.FuncDomain, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	switch {
+	case d.Kind == funcDomainSingle && len(d.Domains) == 1:
+		return functions.NewSingleFuncDomain(d.Domains[0]), nil
+	case d.Kind == funcDomainMultiple:
+		return functions.NewMultipleFuncDomain(d.Domains...), nil
+	default:
+		return nil, fmt.Errorf("%w: %q with %d variable domains", ErrHSConfigDomainKind, d.Kind, len(d.Domains))
+	}
+}
@@ -0,0 +1,219 @@
+package optimize_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestHSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.HSConfig)
+		err    error
+	}{
+		{
+			name:   "Default",
+			modify: func(c *internaloptimize.HSConfig) {},
+		},
+		{
+			name:   "NilFD",
+			modify: func(c *internaloptimize.HSConfig) { c.FD = nil },
+			err:    internaloptimize.ErrHSConfigFD,
+		},
+		{
+			name: "BottomAboveTop",
+			modify: func(c *internaloptimize.HSConfig) {
+				c.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: 1, Top: -1})
+			},
+			err: internaloptimize.ErrHSConfigDomain,
+		},
+		{
+			name: "MultipleBottomAboveTop",
+			modify: func(c *internaloptimize.HSConfig) {
+				c.FD = functions.NewMultipleFuncDomain(
+					functions.VarDomain{Bottom: -1, Top: 1},
+					functions.VarDomain{Bottom: math.NaN(), Top: 1},
+				)
+			},
+			err: internaloptimize.ErrHSConfigDomain,
+		},
+		{
+			name: "StepBottomAboveTop",
+			modify: func(c *internaloptimize.HSConfig) {
+				c.Step = &internaloptimize.HSStep{
+					D: functions.NewSingleFuncDomain(functions.VarDomain{Bottom: 0.1, Top: -0.1}),
+				}
+			},
+			err: internaloptimize.ErrHSConfigDomain,
+		},
+		{
+			name:   "ZeroMemorySize",
+			modify: func(c *internaloptimize.HSConfig) { c.MemorySize = 0 },
+			err:    internaloptimize.ErrHSConfigMemorySize,
+		},
+		{
+			name:   "ProbAboveOne",
+			modify: func(c *internaloptimize.HSConfig) { c.ProbToTakeFromMemory = 1.1 },
+			err:    internaloptimize.ErrHSConfigProbability,
+		},
+		{
+			name:   "ProbNaN",
+			modify: func(c *internaloptimize.HSConfig) { c.ProbToApplyPitchAdjustment = math.NaN() },
+			err:    internaloptimize.ErrHSConfigProbability,
+		},
+		{
+			name:   "NegativeMaxStep",
+			modify: func(c *internaloptimize.HSConfig) { c.MaxStep = -1 },
+			err:    internaloptimize.ErrHSConfigNegative,
+		},
+		{
+			name:   "EmptyStep",
+			modify: func(c *internaloptimize.HSConfig) { c.Step = &internaloptimize.HSStep{} },
+			err:    internaloptimize.ErrHSConfigStep,
+		},
+		{
+			name:   "UnknownReplacement",
+			modify: func(c *internaloptimize.HSConfig) { c.Replacement = 100 },
+			err:    internaloptimize.ErrHSConfigReplacement,
+		},
+		{
+			name:   "LocalSearchWithoutBudget",
+			modify: func(c *internaloptimize.HSConfig) { c.LocalSearch = &internaloptimize.HSLocalSearch{} },
+			err:    internaloptimize.ErrHSConfigLocalSearch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultHSConfig()
+			test.modify(conf)
+
+			err := conf.Validate()
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+
+			_, err = internaloptimize.NewHS(conf)
+			asserting.True(errors.Is(err, test.err), "constructor must validate config")
+		})
+	}
+}
+
+func TestHS_FuncDomainDimension(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  internaloptimize.HSOption
+	}{
+		{
+			name: "FD",
+			opt: internaloptimize.WithHSFD(functions.NewMultipleFuncDomain(
+				functions.VarDomain{Bottom: -10, Top: 10},
+			)),
+		},
+		{
+			name: "Step",
+			opt: internaloptimize.WithHSStep(&internaloptimize.HSStep{D: functions.NewMultipleFuncDomain(
+				functions.VarDomain{Bottom: -1, Top: 1},
+				functions.VarDomain{Bottom: -1, Top: 1},
+				functions.VarDomain{Bottom: -1, Top: 1},
+			)}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+			settings := &optimize.Settings{FuncEvaluations: 100}
+
+			result, err := optimize.Minimize(prob, []float64{1, 1}, settings, internaloptimize.MustHS(nil, test.opt))
+			asserting.True(errors.Is(err, internaloptimize.ErrHSConfigDomainDim), "unexpected error %v", err)
+			asserting.Equal(optimize.Failure, result.Status)
+		})
+	}
+}
+
+func TestNewHS_Options(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultHSConfig()
+
+	_, err := internaloptimize.NewHS(conf, internaloptimize.WithHSMemorySize(0))
+	asserting.True(errors.Is(err, internaloptimize.ErrHSConfigMemorySize))
+	asserting.Equal(internaloptimize.DefaultHSMemorySize, conf.MemorySize, "options must not change passed config")
+
+	hs, err := internaloptimize.NewHS(nil,
+		internaloptimize.WithHSMemorySize(5),
+		internaloptimize.WithHSProbs(0.9, 0.3),
+		internaloptimize.WithHSSeed(1),
+	)
+	asserting.NoError(err)
+	asserting.NotNil(hs)
+
+	_, err = internaloptimize.NewSaHS(nil, internaloptimize.WithHSProbs(2, 0))
+	asserting.True(errors.Is(err, internaloptimize.ErrHSConfigProbability))
+
+	_, err = internaloptimize.NewGHS(nil, internaloptimize.WithHSFD(nil))
+	asserting.True(errors.Is(err, internaloptimize.ErrHSConfigFD))
+}
+
+func TestHSConfig_JSON(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultHSConfig()
+	conf.FD = functions.NewMultipleFuncDomain(
+		functions.VarDomain{Bottom: -1000, Top: 1000},
+		functions.VarDomain{Bottom: -1, Top: 1},
+	)
+	conf.Step = internaloptimize.NewHSStep(10, 0.01)
+	conf.Replacement = internaloptimize.HSRejectDuplicates
+	conf.DuplicateTolerance = 1e-3
+	conf.InitialMemory = []internaloptimize.HSHarmony{{X: []float64{1, 0.5}, F: 2, Evaluated: true}}
+	conf.Seed = 42
+	conf.LocalSearch = internaloptimize.DefaultHSLocalSearch()
+
+	buf := &bytes.Buffer{}
+	asserting.NoError(conf.Save(buf))
+
+	loaded, err := internaloptimize.LoadHSConfig(buf)
+	asserting.NoError(err)
+	asserting.Equal(conf, loaded)
+
+	single := internaloptimize.DefaultHSConfig()
+	single.FD = functions.NewMultipleFuncDomain(functions.VarDomain{Bottom: 0, Top: 1})
+
+	buf.Reset()
+	asserting.NoError(single.Save(buf))
+
+	loaded, err = internaloptimize.LoadHSConfig(buf)
+	asserting.NoError(err)
+	asserting.Equal(single, loaded, "one-variable multiple domain must keep its kind")
+
+	partial, err := internaloptimize.LoadHSConfig(strings.NewReader(`{"MemorySize": 10}`))
+	asserting.NoError(err)
+
+	expected := internaloptimize.DefaultHSConfig()
+	expected.MemorySize = 10
+	asserting.Equal(expected, partial, "missing fields must be taken from defaults")
+
+	_, err = internaloptimize.LoadHSConfig(strings.NewReader(`{"ProbToTakeFromMemory": -1}`))
+	asserting.True(errors.Is(err, internaloptimize.ErrHSConfigProbability))
+
+	_, err = internaloptimize.LoadHSConfig(strings.NewReader(`{"FD": {"Kind": "ring", "Domains": []}}`))
+	asserting.True(errors.Is(err, internaloptimize.ErrHSConfigDomainKind), "unexpected error %v", err)
+}
@@ -0,0 +1,200 @@
+package optimize
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultHSLocalSearchHarmonies       = 1
+	DefaultHSLocalSearchFuncEvaluations = 200
+)
+
+var ErrHSLocalSearchStopped = errors.New("harmony search is terminated during local search")
+
+// HSLocalSearchStopped доводка прервана, потому что оптимизация завершена.
+var HSLocalSearchStopped = optimize.NewStatus("HSLocalSearchStopped", true, ErrHSLocalSearchStopped)
+
+// HSLocalSearch настройки доводки лучших гармоний локальным методом.
+//
+// Локальный метод работает внутри FD: значения вычисляются в ближайшей точке области определения,
+// а за выход из нее начисляется квадратичный штраф. Вычисления идут через HS и учитываются
+// в ограничениях оптимизации.
+type HSLocalSearch struct {
+	// Method локальный метод, grad - есть ли у задачи градиент. nil - BFGS при наличии градиента, иначе NelderMead.
+	Method func(grad bool) optimize.Method `json:"-"`
+	// Period доводка каждые Period импровизаций, 0 - только при завершении поиска по условиям HS:
+	// HSConfig.StagnationLimit или HSConfig.MemoryTolerance. При остановке по ограничениям optimize.Settings
+	// или критерию остановки optimize.Minimize вычисления больше недоступны и доводки не будет.
+	Period          int
+	Harmonies       int // количество лучших гармоний для доводки.
+	FuncEvaluations int // количество вычислений функции на доводку одной гармонии.
+}
+
+func DefaultHSLocalSearch() *HSLocalSearch {
+	return &HSLocalSearch{
+		Harmonies:       DefaultHSLocalSearchHarmonies,
+		FuncEvaluations: DefaultHSLocalSearchFuncEvaluations,
+	}
+}
+
+// method локальный метод для задачи с градиентом или без.
+func (l *HSLocalSearch) method(grad bool) optimize.Method {
+	if l.Method != nil {
+		return l.Method(grad)
+	}
+
+	if grad {
+		return &optimize.BFGS{}
+	}
+
+	return &optimize.NelderMead{}
+}
+
+// polishDue пора ли доводить гармонии.
+func (h *HS) polishDue() bool {
+	l := h.conf.LocalSearch
+
+	return l != nil && l.Period > 0 && h.state.improvisations%l.Period == 0
+}
+
+// polish доводка лучших гармоний и запись улучшенных в память.
+// Вернет false, если оптимизация завершена.
+func (h *HS) polish(operation chan<- optimize.Task, result <-chan optimize.Task) bool {
+	l := h.conf.LocalSearch
+	if l == nil {
+		return true
+	}
+
+	n := l.Harmonies
+	if n <= 0 || n > len(h.state.memory) {
+		n = len(h.state.memory)
+	}
+
+	// лучшие гармонии в конце памяти, после доводки память пересортируется.
+	polished := make([]*memoryComponent, n)
+	copy(polished, h.state.memory[len(h.state.memory)-n:])
+
+	bestF := h.best().F
+	improved := false
+
+	for _, m := range polished {
+		x, f, ok := h.localSearch(operation, result, m)
+		if !ok {
+			return false
+		}
+
+		if f < m.F {
+			copy(m.X, x)
+			m.F = f
+			m.born = h.state.improvisations
+			improved = true
+		}
+	}
+
+	if !improved {
+		return true
+	}
+
+	h.sortMemory()
+
+	h.state.current = append([]float64(nil), h.best().X...)
+
+	if h.best().F < bestF {
+		h.state.stagnation = 0
+	}
+
+	return majorIterationAndWait(operation, result, h.best().X, h.best().F)
+}
+
+// localSearch доводка гармонии m локальным методом. Вернет лучшую вычисленную точку области определения
+// и false, если оптимизация завершена.
+func (h *HS) localSearch(operation chan<- optimize.Task, result <-chan optimize.Task,
+	m *memoryComponent) ([]float64, float64, bool) {
+	l := h.conf.LocalSearch
+	grad := h.available.Grad
+
+	bestX := append([]float64(nil), m.X...)
+	bestF := m.F
+	stopped := false
+
+	projected := make([]float64, h.state.dim)
+
+	// project ближайшая к x точка области определения, вернет квадрат расстояния до нее.
+	project := func(x []float64) float64 {
+		penalty := 0.0
+
+		for i, v := range x {
+			d := h.conf.FD.VarDomain(i)
+			projected[i] = d.Normalize(v)
+			penalty += (v - projected[i]) * (v - projected[i])
+		}
+
+		return penalty
+	}
+
+	prob := optimize.Problem{
+		Func: func(x []float64) float64 {
+			penalty := project(x)
+
+			f, ok := h.evaluate(operation, result, append([]float64(nil), projected...))
+			if !ok {
+				stopped = true
+
+				return math.Inf(1)
+			}
+
+			if f < bestF {
+				bestF = f
+				copy(bestX, projected)
+			}
+
+			return f + penalty
+		},
+		Status: func() (optimize.Status, error) {
+			if stopped {
+				return HSLocalSearchStopped, nil
+			}
+
+			return optimize.NotTerminated, nil
+		},
+	}
+
+	if grad {
+		prob.Grad = func(g, x []float64) {
+			project(x)
+
+			r, ok := gradEvaluation(operation, result, append([]float64(nil), projected...))
+			if !ok {
+				stopped = true
+
+				for i := range g {
+					g[i] = 0
+				}
+
+				return
+			}
+
+			for i := range g {
+				if x[i] != projected[i] {
+					g[i] = 2 * (x[i] - projected[i])
+
+					continue
+				}
+
+				g[i] = r[i]
+			}
+		}
+	}
+
+	settings := &optimize.Settings{
+		FuncEvaluations: l.FuncEvaluations,
+		GradEvaluations: l.FuncEvaluations,
+	}
+
+	_, _ = optimize.Minimize(prob, append([]float64(nil), m.X...), settings, l.method(grad))
+
+	return bestX, bestF, !stopped
+}
@@ -0,0 +1,154 @@
+package optimize_test
+
+import (
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestHS_LocalSearch(t *testing.T) {
+	tests := []struct {
+		name       string
+		grad       *fd.Settings
+		period     int
+		stagnation int
+		status     optimize.Status
+	}{
+		{
+			name:   "NelderMeadPeriodic",
+			period: 200,
+			status: optimize.FunctionEvaluationLimit,
+		},
+		{
+			name:   "BFGSPeriodic",
+			grad:   &fd.Settings{},
+			period: 200,
+			status: optimize.FunctionEvaluationLimit,
+		},
+		{
+			name:       "NelderMeadAtEnd",
+			stagnation: 300,
+			status:     internaloptimize.HSStagnation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			d := functions.VarDomain{Bottom: -5, Top: 5}
+			conf := internaloptimize.DefaultHSConfig()
+			conf.FD = functions.NewSingleFuncDomain(d)
+			conf.MemorySize = 10
+			conf.MemoryTolerance = 0
+			conf.StagnationLimit = test.stagnation
+			conf.Seed = 7
+			conf.LocalSearch = internaloptimize.DefaultHSLocalSearch()
+			conf.LocalSearch.Period = test.period
+
+			prob := functions.MustProblem(functions.Himmelblau, test.grad, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 3000,
+			}
+
+			hs := internaloptimize.MustHS(conf)
+
+			result, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings, hs)
+			asserting.NoError(err)
+			asserting.Equal(test.status, result.Status)
+			asserting.Less(result.F, 1e-8)
+
+			memory := hs.Memory()
+			asserting.Equal(result.F, memory[0].F)
+			asserting.Equal(prob.Func(memory[0].X), memory[0].F, "polished harmony value must match its point")
+
+			for _, v := range memory[0].X {
+				asserting.NoError(d.Validate(v))
+			}
+		})
+	}
+}
+
+func TestHS_LocalSearchAtEnd(t *testing.T) {
+	tests := []struct {
+		name       string
+		stagnation int
+		status     optimize.Status
+		polished   bool
+	}{
+		{
+			name:       "Stagnation",
+			stagnation: 300,
+			status:     internaloptimize.HSStagnation,
+			polished:   true,
+		},
+		{
+			name:   "FuncEvaluations",
+			status: optimize.FunctionEvaluationLimit,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			polishes := 0
+
+			conf := internaloptimize.DefaultHSConfig()
+			conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -5, Top: 5})
+			conf.MemorySize = 10
+			conf.MemoryTolerance = 0
+			conf.StagnationLimit = test.stagnation
+			conf.Seed = 7
+			conf.LocalSearch = internaloptimize.DefaultHSLocalSearch()
+			conf.LocalSearch.Method = func(bool) optimize.Method {
+				polishes++
+
+				return &optimize.NelderMead{}
+			}
+
+			prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 3000,
+			}
+
+			result, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings, internaloptimize.MustHS(conf))
+			asserting.NoError(err)
+			asserting.Equal(test.status, result.Status)
+			asserting.Equal(test.polished, polishes > 0)
+		})
+	}
+}
+
+func TestHS_LocalSearchBounds(t *testing.T) {
+	asserting := assert.New(t)
+
+	// минимум x + y в углу области определения, локальный метод тянет за ее пределы.
+	d := functions.VarDomain{Bottom: -1, Top: 1}
+	conf := internaloptimize.DefaultHSConfig()
+	conf.FD = functions.NewSingleFuncDomain(d)
+	conf.MemorySize = 5
+	conf.LocalSearch = internaloptimize.DefaultHSLocalSearch()
+	conf.LocalSearch.Period = 50
+
+	prob := functions.MustProblem("x + y", nil, nil)
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: 500,
+	}
+
+	result, err := optimize.Minimize(prob, []float64{0.5, 0.5}, settings, internaloptimize.MustHS(conf))
+	asserting.NoError(err)
+
+	for _, v := range result.X {
+		asserting.NoError(d.Validate(v))
+	}
+
+	asserting.InDelta(-2, result.F, 1e-6)
+}
@@ -0,0 +1,272 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// HSReplacement политика замены гармоний в памяти новой импровизацией.
+type HSReplacement int
+
+const (
+	// HSReplaceWorst импровизация заменяет худшую гармонию, если лучше нее.
+	HSReplaceWorst HSReplacement = iota
+	// HSReplaceMostSimilar импровизация заменяет ближайшую гармонию, если лучше нее (crowding).
+	HSReplaceMostSimilar
+	// HSRejectDuplicates как HSReplaceWorst, но импровизация отклоняется,
+	// если в памяти есть гармония ближе HSConfig.DuplicateTolerance.
+	HSRejectDuplicates
+	// HSReplaceOldest импровизация заменяет самую старую из гармоний, которые хуже нее.
+	HSReplaceOldest
+)
+
+// HSHarmony гармония из памяти.
+type HSHarmony struct {
+	X         []float64
+	F         float64
+	Evaluated bool // известно ли значение F, иначе оно будет вычислено.
+}
+
+// HSHarmonyFromResult гармония из результата предыдущей оптимизации.
+func HSHarmonyFromResult(res *optimize.Result) HSHarmony {
+	return HSHarmony{
+		X:         append([]float64(nil), res.X...),
+		F:         res.F,
+		Evaluated: true,
+	}
+}
+
+// Memory копия памяти гармоний от лучшей к худшей.
+func (h *HS) Memory() []HSHarmony {
+	if h.state == nil {
+		return nil
+	}
+
+	memory := make([]HSHarmony, len(h.state.memory))
+
+	for i, m := range h.state.memory {
+		memory[len(memory)-1-i] = HSHarmony{
+			X:         append([]float64(nil), m.X...),
+			F:         m.F,
+			Evaluated: true,
+		}
+	}
+
+	return memory
+}
+
+// seeds начальные гармонии: стартовая точка и HSConfig.InitialMemory.
+//
+// Гармонии приводятся к области определения, дубликаты отбрасываются.
+func (h *HS) seeds(start HSHarmony) ([]HSHarmony, error) {
+	seeds := make([]HSHarmony, 0, len(h.conf.InitialMemory)+1)
+
+	for _, seed := range append([]HSHarmony{start}, h.conf.InitialMemory...) {
+		if len(seed.X) != h.state.dim {
+			return nil, fmt.Errorf("%w: %v", ErrHSSeedDimension, seed.X)
+		}
+
+		seed = h.normalizeHarmony(seed)
+
+		duplicate := false
+
+		for _, exists := range seeds {
+			if floats.Distance(exists.X, seed.X, math.Inf(1)) <= h.conf.DuplicateTolerance {
+				duplicate = true
+
+				break
+			}
+		}
+
+		if !duplicate {
+			seeds = append(seeds, seed)
+		}
+	}
+
+	return seeds, nil
+}
+
+// normalizeHarmony копия гармонии в пределах области определения.
+//
+// Если гармония была вне области определения, то ее значение придется вычислить заново.
+func (h *HS) normalizeHarmony(harmony HSHarmony) HSHarmony {
+	x := make([]float64, len(harmony.X))
+
+	for i, v := range harmony.X {
+		d := h.conf.FD.VarDomain(i)
+		x[i] = d.Normalize(v)
+	}
+
+	if !floats.Equal(x, harmony.X) {
+		harmony.Evaluated = false
+	}
+
+	harmony.X = x
+
+	return harmony
+}
+
+// randomHarmony случайная гармония из области определения.
+func (h *HS) randomHarmony() HSHarmony {
+	x := make([]float64, h.state.dim)
+
+	for i := range x {
+		x[i] = h.state.rnd.ValueVarInDomain(i, h.conf.FD)
+	}
+
+	return HSHarmony{X: x}
+}
+
+// initMemory заполнение памяти начальными гармониями, а оставшихся мест случайными.
+//
+// Если начальных гармоний больше размера памяти, то остаются лучшие.
+// Вернет false, если оптимизация завершена.
+func (h *HS) initMemory(operation chan<- optimize.Task, result <-chan optimize.Task, seeds []HSHarmony) bool {
+	harmonies := h.memoryCandidates(seeds)
+
+	for i := range harmonies {
+		harmony := &harmonies[i]
+
+		if !harmony.Evaluated {
+			f, ok := h.evaluate(operation, result, harmony.X)
+			if !ok {
+				return false
+			}
+
+			harmony.F = f
+		}
+
+		if h.checkNaN(harmony.X, harmony.F) {
+			methodDone(operation, harmony.X, harmony.F)
+
+			return false
+		}
+	}
+
+	h.fillMemory(harmonies)
+
+	return majorIterationAndWait(operation, result, h.best().X, h.best().F)
+}
+
+// memoryCandidates начальные гармонии, дополненные случайными до размера памяти.
+func (h *HS) memoryCandidates(seeds []HSHarmony) []HSHarmony {
+	harmonies := append([]HSHarmony(nil), seeds...)
+	for len(harmonies) < h.conf.MemorySize {
+		harmonies = append(harmonies, h.randomHarmony())
+	}
+
+	return harmonies
+}
+
+// fillMemory заполнение памяти вычисленными гармониями, если их больше размера памяти, то остаются лучшие.
+func (h *HS) fillMemory(harmonies []HSHarmony) {
+	memory := make([]*memoryComponent, len(harmonies))
+	for i, harmony := range harmonies {
+		memory[i] = &memoryComponent{X: harmony.X, F: harmony.F}
+	}
+
+	h.state.memory = memory
+	h.sortMemory()
+	h.state.memory = h.state.memory[len(memory)-h.conf.MemorySize:]
+}
+
+// updateMemory попытка поместить импровизацию x со значением f в память.
+//
+// В память копируется значение x, поэтому после вызова x можно изменять.
+func (h *HS) updateMemory(x []float64, f float64) bool {
+	i := h.replacementIndex(x, f)
+	if i < 0 {
+		return false
+	}
+
+	m := h.state.memory[i]
+	copy(m.X, x)
+	m.F = f
+	m.born = h.state.improvisations
+
+	return true
+}
+
+// replacementIndex индекс заменяемой гармонии по политике HSConfig.Replacement, -1 если замены нет.
+func (h *HS) replacementIndex(x []float64, f float64) int {
+	switch h.conf.Replacement {
+	default:
+		panic("unknown replacement policy")
+	case HSReplaceWorst:
+		return h.replaceWorst(f)
+	case HSReplaceMostSimilar:
+		return h.replaceMostSimilar(x, f)
+	case HSRejectDuplicates:
+		if h.hasDuplicate(x) {
+			return -1
+		}
+
+		return h.replaceWorst(f)
+	case HSReplaceOldest:
+		return h.replaceOldest(f)
+	}
+}
+
+func (h *HS) replaceWorst(f float64) int {
+	worst := 0
+
+	for i, m := range h.state.memory {
+		if m.F > h.state.memory[worst].F {
+			worst = i
+		}
+	}
+
+	if f < h.state.memory[worst].F {
+		return worst
+	}
+
+	return -1
+}
+
+func (h *HS) replaceMostSimilar(x []float64, f float64) int {
+	similar := 0
+	minDistance := math.Inf(1)
+
+	for i, m := range h.state.memory {
+		distance := floats.Distance(m.X, x, 2)
+		if distance < minDistance {
+			similar = i
+			minDistance = distance
+		}
+	}
+
+	if f < h.state.memory[similar].F {
+		return similar
+	}
+
+	return -1
+}
+
+func (h *HS) hasDuplicate(x []float64) bool {
+	for _, m := range h.state.memory {
+		if floats.Distance(m.X, x, math.Inf(1)) <= h.conf.DuplicateTolerance {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *HS) replaceOldest(f float64) int {
+	oldest := -1
+
+	for i, m := range h.state.memory {
+		if f >= m.F {
+			continue
+		}
+
+		if oldest < 0 || m.born < h.state.memory[oldest].born {
+			oldest = i
+		}
+	}
+
+	return oldest
+}
@@ -0,0 +1,154 @@
+package optimize_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestHS_Memory(t *testing.T) {
+	const duplicateTolerance = 1e-1
+
+	tests := []struct {
+		name        string
+		replacement internaloptimize.HSReplacement
+	}{
+		{name: "ReplaceWorst", replacement: internaloptimize.HSReplaceWorst},
+		{name: "ReplaceMostSimilar", replacement: internaloptimize.HSReplaceMostSimilar},
+		{name: "RejectDuplicates", replacement: internaloptimize.HSRejectDuplicates},
+		{name: "ReplaceOldest", replacement: internaloptimize.HSReplaceOldest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			d := functions.VarDomain{Bottom: -10, Top: 10}
+			conf := &internaloptimize.HSConfig{
+				FD:                         functions.NewSingleFuncDomain(d),
+				MemorySize:                 10,
+				ProbToTakeFromMemory:       internaloptimize.DefaultProbToTakeFromMemory,
+				ProbToApplyPitchAdjustment: internaloptimize.DefaultProbToApplyPitchAdjustment,
+				MaxStep:                    internaloptimize.DefaultMaxStep,
+				Replacement:                test.replacement,
+				DuplicateTolerance:         duplicateTolerance,
+			}
+			prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 2000,
+			}
+
+			hs := internaloptimize.MustHS(conf)
+
+			result, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings, hs)
+			asserting.NoError(err)
+
+			memory := hs.Memory()
+			asserting.Len(memory, conf.MemorySize)
+			asserting.Equal(result.F, memory[0].F)
+
+			for i, m := range memory {
+				asserting.Equal(prob.Func(m.X), m.F, "harmony value must match its point")
+
+				for _, v := range m.X {
+					asserting.NoError(d.Validate(v))
+				}
+
+				if i > 0 {
+					asserting.LessOrEqual(memory[i-1].F, m.F, "memory must be sorted from best to worst")
+				}
+			}
+
+			if test.replacement != internaloptimize.HSRejectDuplicates {
+				return
+			}
+
+			for i := range memory {
+				for j := i + 1; j < len(memory); j++ {
+					asserting.Greater(floats.Distance(memory[i].X, memory[j].X, math.Inf(1)), duplicateTolerance)
+				}
+			}
+		})
+	}
+}
+
+func TestHS_InitialMemory(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultHSConfig()
+	conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+	conf.MemorySize = 5
+	conf.InitialMemory = []internaloptimize.HSHarmony{
+		{X: []float64{1, 1}},
+		{X: []float64{1, 1}},
+		{X: []float64{20, 0}},
+		{X: []float64{3, 2}, F: -1, Evaluated: true},
+	}
+
+	prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+	// стартовая точка, две начальные гармонии без значений и одна случайная, а затем одна импровизация,
+	// результат которой уже не попадет в память.
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: 5,
+	}
+
+	hs := internaloptimize.MustHS(conf)
+
+	result, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings, hs)
+	asserting.NoError(err)
+	asserting.Equal(-1.0, result.F)
+
+	memory := hs.Memory()
+	asserting.Len(memory, conf.MemorySize)
+	asserting.Equal([]float64{3, 2}, memory[0].X)
+
+	count := func(x []float64) int {
+		n := 0
+
+		for _, m := range memory {
+			if floats.Equal(m.X, x) {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	asserting.Equal(1, count([]float64{1, 1}))
+	asserting.Equal(1, count([]float64{10, 0}))
+	asserting.Equal(1, count([]float64{5, 5}))
+}
+
+func TestHS_InitialMemoryFromPreviousRun(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultHSConfig()
+	conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+
+	prob := functions.MustProblem(functions.Levi13, nil, nil)
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: 1000,
+	}
+
+	first := internaloptimize.MustHS(conf)
+
+	firstResult, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings, first)
+	asserting.NoError(err)
+
+	conf.InitialMemory = append(first.Memory(), internaloptimize.HSHarmonyFromResult(firstResult))
+
+	second := internaloptimize.MustHS(conf)
+
+	secondResult, err := optimize.Minimize(prob, []float64{5.0, 5.0}, settings, second)
+	asserting.NoError(err)
+	asserting.LessOrEqual(secondResult.F, firstResult.F)
+	asserting.Len(second.Memory(), conf.MemorySize)
+}
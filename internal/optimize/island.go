@@ -0,0 +1,327 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultIslands           = 4
+	DefaultIslandInterval    = 500
+	DefaultIslandMigrants    = 2
+	DefaultIslandEvaluations = 20_000
+)
+
+var (
+	ErrIslandCount     = errors.New("island model needs at least two islands")
+	ErrIslandTopology  = errors.New("unknown island topology")
+	ErrIslandMigration = errors.New("migration interval and migrants must be positive")
+)
+
+// IslandTopology по каким связям острова обмениваются гармониями.
+type IslandTopology int
+
+const (
+	// IslandRing остров i отправляет гармонии острову i+1, последний - первому.
+	IslandRing IslandTopology = iota
+	// IslandFull каждый остров отправляет гармонии всем остальным.
+	IslandFull
+	// IslandRandom каждый остров отправляет гармонии случайно выбранному при каждом обмене острову.
+	IslandRandom
+)
+
+// IslandConfig настройки островной модели гармонического поиска.
+type IslandConfig struct {
+	// Islands настройки HS каждого острова, nil - DefaultHSConfig. Острову без HSConfig.Seed
+	// зерно выдается из Seed.
+	Islands  []*HSConfig
+	Topology IslandTopology
+	Interval int // обмен гармониями каждые Interval импровизаций острова.
+	Migrants int // лучших гармоний, отправляемых за один обмен.
+	// FuncEvaluations вычислений функции каждого острова, 0 - без ограничения.
+	FuncEvaluations int
+	// Converger новый критерий остановки для каждого острова, nil - критерий optimize.Minimize по умолчанию.
+	Converger func() optimize.Converger `json:"-"`
+	Seed      uint64                    // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultIslandConfig() *IslandConfig {
+	return &IslandConfig{
+		Islands:         make([]*HSConfig, DefaultIslands),
+		Topology:        IslandRing,
+		Interval:        DefaultIslandInterval,
+		Migrants:        DefaultIslandMigrants,
+		FuncEvaluations: DefaultIslandEvaluations,
+	}
+}
+
+func (c *IslandConfig) Validate() error {
+	if len(c.Islands) < 2 { //nolint
+		return fmt.Errorf("%w: %d", ErrIslandCount, len(c.Islands))
+	}
+
+	for i, conf := range c.Islands {
+		if conf == nil {
+			continue
+		}
+
+		if err := conf.Validate(); err != nil {
+			return fmt.Errorf("island %d: %w", i, err)
+		}
+	}
+
+	if c.Topology < IslandRing || c.Topology > IslandRandom {
+		return fmt.Errorf("%w: %d", ErrIslandTopology, c.Topology)
+	}
+
+	if c.Interval < 1 || c.Migrants < 1 {
+		return fmt.Errorf("%w: Interval %d, Migrants %d", ErrIslandMigration, c.Interval, c.Migrants)
+	}
+
+	if c.FuncEvaluations < 0 {
+		return fmt.Errorf("%w: FuncEvaluations %d", ErrConfigNegative, c.FuncEvaluations)
+	}
+
+	return nil
+}
+
+// IslandResult итоги островной модели.
+type IslandResult struct {
+	*optimize.Result                    // результат лучшего острова.
+	Islands          []*optimize.Result // результаты всех островов в порядке IslandConfig.Islands.
+	Best             int                // номер лучшего острова.
+	Migrants         int                // гармоний других островов, принятых в память.
+}
+
+// Islands островная модель: экземпляры HS с разными настройками ищут минимум одновременно,
+// каждый в своей горутине, и раз в Interval импровизаций отправляют лучшие гармонии соседям по Topology.
+// Острова - только HS: GHS, SaHS и популяционные методы пакета островами быть не могут.
+//
+// Гармония соседа, вышедшая за FD острова, не принимается: ее значение вычислено в другой точке.
+//
+// Обмен асинхронный: остров забирает пришедшие гармонии при своем очередном обмене и не ждет соседей,
+// поэтому результат с одинаковым Seed может отличаться от запуска к запуску.
+type Islands struct {
+	conf *IslandConfig
+}
+
+// NewIslands создать островную модель. Настройки conf, по умолчанию DefaultIslandConfig, копируются.
+func NewIslands(conf *IslandConfig) (*Islands, error) {
+	if conf == nil {
+		conf = DefaultIslandConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	c.Islands = append([]*HSConfig(nil), c.Islands...)
+
+	return &Islands{conf: &c}, nil
+}
+
+func MustIslands(conf *IslandConfig) *Islands {
+	m, err := NewIslands(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Minimize поиск минимума задачи p всеми островами из стартовой точки x.
+// Вернет ошибку, если хотя бы один остров завершился с ошибкой.
+func (m *Islands) Minimize(p optimize.Problem, x []float64) (*IslandResult, error) {
+	c := m.conf
+	n := len(c.Islands)
+	rnd := random.NewGenerator(c.Seed)
+
+	inboxes := make([]*islandInbox, n)
+	for i := range inboxes {
+		inboxes[i] = &islandInbox{capacity: c.Migrants * (n - 1)}
+	}
+
+	methods := make([]*HS, n)
+
+	for i, conf := range c.Islands {
+		if conf == nil {
+			conf = DefaultHSConfig()
+		}
+
+		var opts []HSOption
+		if conf.Seed == 0 {
+			opts = append(opts, WithHSSeed(rnd.Uint64()))
+		}
+
+		h, err := NewHS(conf, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("island %d: %w", i, err)
+		}
+
+		h.migration = &hsMigration{
+			interval: c.Interval,
+			migrants: c.Migrants,
+			inbox:    inboxes[i],
+			targets:  m.targets(i, inboxes, random.NewGenerator(rnd.Uint64())),
+		}
+		methods[i] = h
+	}
+
+	res := &IslandResult{Islands: make([]*optimize.Result, n)}
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+
+	for i, h := range methods {
+		settings := &optimize.Settings{FuncEvaluations: c.FuncEvaluations}
+		if c.Converger != nil {
+			settings.Converger = c.Converger()
+		}
+
+		wg.Add(1)
+
+		go func(i int, h *HS, settings *optimize.Settings) {
+			defer wg.Done()
+
+			res.Islands[i], errs[i] = optimize.Minimize(p, append([]float64(nil), x...), settings, h)
+		}(i, h, settings)
+	}
+
+	wg.Wait()
+
+	for i, r := range res.Islands {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("island %d: %w", i, errs[i])
+		}
+
+		if r.F < res.Islands[res.Best].F {
+			res.Best = i
+		}
+
+		res.Migrants += methods[i].migration.accepted
+	}
+
+	res.Result = res.Islands[res.Best]
+
+	return res, nil
+}
+
+// targets функция выбора входящих ящиков соседей острова i при очередном обмене.
+func (m *Islands) targets(i int, inboxes []*islandInbox, rnd *random.Generator) func() []*islandInbox {
+	n := len(inboxes)
+
+	switch m.conf.Topology {
+	case IslandFull:
+		others := make([]*islandInbox, 0, n-1)
+		for j, inbox := range inboxes {
+			if j != i {
+				others = append(others, inbox)
+			}
+		}
+
+		return func() []*islandInbox { return others }
+	case IslandRandom:
+		return func() []*islandInbox {
+			j := rnd.Intn(n - 1)
+			if j >= i {
+				j++
+			}
+
+			return []*islandInbox{inboxes[j]}
+		}
+	default:
+		next := []*islandInbox{inboxes[(i+1)%n]}
+
+		return func() []*islandInbox { return next }
+	}
+}
+
+// islandInbox гармонии, отправленные острову и еще не принятые им.
+// Хранятся только capacity последних гармоний, чтобы ящик завершившегося острова не рос.
+type islandInbox struct {
+	mu        sync.Mutex
+	capacity  int
+	harmonies []HSHarmony
+}
+
+func (b *islandInbox) put(harmonies []HSHarmony) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.harmonies = append(b.harmonies, harmonies...)
+	if extra := len(b.harmonies) - b.capacity; extra > 0 {
+		b.harmonies = append(b.harmonies[:0], b.harmonies[extra:]...)
+	}
+}
+
+func (b *islandInbox) take() []HSHarmony {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	harmonies := b.harmonies
+	b.harmonies = nil
+
+	return harmonies
+}
+
+// hsMigration обмен гармониями острова с соседями.
+type hsMigration struct {
+	interval int
+	migrants int
+	inbox    *islandInbox
+	targets  func() []*islandInbox
+
+	accepted int // гармоний соседей, принятых в память.
+}
+
+// migrationDue пора ли обмениваться гармониями.
+func (h *HS) migrationDue() bool {
+	return h.migration != nil && h.state.improvisations%h.migration.interval == 0
+}
+
+// migrate отправка лучших гармоний соседям и прием пришедших гармоний в память
+// по политике HSConfig.Replacement.
+func (h *HS) migrate() {
+	mg := h.migration
+
+	memory := h.Memory()
+	if len(memory) > mg.migrants {
+		memory = memory[:mg.migrants]
+	}
+
+	for _, inbox := range mg.targets() {
+		inbox.put(memory)
+	}
+
+	bestF := h.best().F
+	improved := false
+
+	for _, harmony := range mg.inbox.take() {
+		if !inDomain(harmony.X, h.conf.FD) {
+			continue
+		}
+
+		// одна гармония могла уйти нескольким островам, поэтому ее точка копируется.
+		x := append([]float64(nil), harmony.X...)
+		if h.updateMemory(x, harmony.F) {
+			mg.accepted++
+			improved = true
+		}
+	}
+
+	if !improved {
+		return
+	}
+
+	h.sortMemory()
+
+	if h.best().F < bestF {
+		h.state.current = append([]float64(nil), h.best().X...)
+		h.state.stagnation = 0
+	}
+}
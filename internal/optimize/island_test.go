@@ -0,0 +1,176 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestIslands_Minimize(t *testing.T) {
+	fd := functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+
+	topologies := []struct {
+		name     string
+		topology internaloptimize.IslandTopology
+	}{
+		{name: "Ring", topology: internaloptimize.IslandRing},
+		{name: "Full", topology: internaloptimize.IslandFull},
+		{name: "Random", topology: internaloptimize.IslandRandom},
+	}
+
+	problems := []struct {
+		name string
+		expr string
+	}{
+		{name: "Himmelblau", expr: functions.Himmelblau},
+		{name: "Levi13", expr: functions.Levi13},
+	}
+
+	for _, topology := range topologies {
+		for _, problem := range problems {
+			t.Run(topology.name+"/"+problem.name, func(t *testing.T) {
+				asserting := assert.New(t)
+
+				conf := internaloptimize.DefaultIslandConfig()
+				conf.Islands = []*internaloptimize.HSConfig{
+					internaloptimize.DefaultHSConfig(),
+					{FD: fd, MemorySize: 10, ProbToTakeFromMemory: 0.9, ProbToApplyPitchAdjustment: 0.3},
+					{FD: fd, MemorySize: 100, ProbToTakeFromMemory: 0.7, ProbToApplyPitchAdjustment: 0.7},
+					nil,
+				}
+				conf.Islands[0].FD = fd
+				conf.Topology = topology.topology
+				conf.Interval = 100
+				conf.Converger = func() optimize.Converger { return optimize.NeverTerminate{} }
+				conf.Seed = 3
+
+				prob := functions.MustProblem(problem.expr, nil, nil)
+
+				result, err := internaloptimize.MustIslands(conf).Minimize(prob, []float64{9, 9})
+				asserting.NoError(err)
+				asserting.Len(result.Islands, 4)
+				asserting.Greater(result.Migrants, 0)
+				asserting.InDelta(0, result.F, 1e-3)
+
+				for _, island := range result.Islands {
+					asserting.LessOrEqual(result.F, island.F)
+					asserting.LessOrEqual(island.FuncEvaluations, conf.FuncEvaluations)
+				}
+			})
+		}
+	}
+}
+
+func TestIslands_MinimizeDomains(t *testing.T) {
+	asserting := assert.New(t)
+
+	// минимум в (-5, -5) лежит только в области первого острова, гармонии оттуда второму острову чужие.
+	conf := internaloptimize.DefaultIslandConfig()
+	conf.Islands = []*internaloptimize.HSConfig{
+		internaloptimize.DefaultHSConfig(),
+		internaloptimize.DefaultHSConfig(),
+	}
+	conf.Islands[0].FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+	conf.Islands[1].FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: 0, Top: 10})
+	conf.Interval = 100
+	conf.FuncEvaluations = 5_000
+	conf.Converger = func() optimize.Converger { return optimize.NeverTerminate{} }
+	conf.Seed = 3
+
+	for _, island := range conf.Islands {
+		island.MemoryTolerance = 0
+	}
+
+	prob := functions.MustProblem("(x + 5) ** 2 + (y + 5) ** 2", nil, nil)
+
+	result, err := internaloptimize.MustIslands(conf).Minimize(prob, []float64{9, 9})
+	asserting.NoError(err)
+	asserting.Equal(0, result.Best)
+	asserting.GreaterOrEqual(result.Islands[1].F, 50.0)
+
+	for _, island := range result.Islands {
+		asserting.Equal(prob.Func(island.X), island.F, "island value must match its point")
+	}
+}
+
+func TestIslands_MinimizeError(t *testing.T) {
+	conf := internaloptimize.DefaultIslandConfig()
+	conf.Islands = []*internaloptimize.HSConfig{
+		internaloptimize.DefaultHSConfig(),
+		{
+			FD:            internaloptimize.DefaultHSFD,
+			MemorySize:    10,
+			InitialMemory: []internaloptimize.HSHarmony{{X: []float64{1, 2, 3}}},
+		},
+	}
+
+	prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+
+	_, err := internaloptimize.MustIslands(conf).Minimize(prob, []float64{9, 9})
+	assert.True(t, errors.Is(err, internaloptimize.ErrHSSeedDimension), "unexpected error %v", err)
+}
+
+func TestIslandConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.IslandConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.IslandConfig) {}},
+		{
+			name:   "OneIsland",
+			modify: func(c *internaloptimize.IslandConfig) { c.Islands = c.Islands[:1] },
+			err:    internaloptimize.ErrIslandCount,
+		},
+		{
+			name: "InvalidIsland",
+			modify: func(c *internaloptimize.IslandConfig) {
+				c.Islands[1] = internaloptimize.DefaultHSConfig()
+				c.Islands[1].MemorySize = 0
+			},
+			err: internaloptimize.ErrHSConfigMemorySize,
+		},
+		{
+			name:   "UnknownTopology",
+			modify: func(c *internaloptimize.IslandConfig) { c.Topology = 10 },
+			err:    internaloptimize.ErrIslandTopology,
+		},
+		{
+			name:   "ZeroInterval",
+			modify: func(c *internaloptimize.IslandConfig) { c.Interval = 0 },
+			err:    internaloptimize.ErrIslandMigration,
+		},
+		{
+			name:   "ZeroMigrants",
+			modify: func(c *internaloptimize.IslandConfig) { c.Migrants = 0 },
+			err:    internaloptimize.ErrIslandMigration,
+		},
+		{
+			name:   "NegativeEvaluations",
+			modify: func(c *internaloptimize.IslandConfig) { c.FuncEvaluations = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultIslandConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewIslands(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
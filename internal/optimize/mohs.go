@@ -0,0 +1,356 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultMOHSMemorySize                 = 100
+	DefaultMOHSFuncEvaluations            = 10_000
+	DefaultMOHSProbToTakeFromMemory       = 0.95
+	DefaultMOHSProbToApplyPitchAdjustment = 0.3
+)
+
+var ErrMOHSDimension = errors.New("start point dimension does not match the objectives")
+
+// MultiObjective векторная целевая функция, например functions.MultiExpression.
+type MultiObjective interface {
+	// Funcs значения всех целевых функций в точке x, записываются в dst.
+	Funcs(dst, x []float64)
+	Dimension() int
+	Objectives() int
+}
+
+// MOHSConfig настройки многокритериального гармонического поиска.
+type MOHSConfig struct {
+	FD                         functions.FuncDomain
+	MemorySize                 int     // наибольший размер архива недоминируемых гармоний.
+	ProbToTakeFromMemory       float64 // вероятность взять значение из архива, иначе возьмем рандомное значение.
+	ProbToApplyPitchAdjustment float64 // вероятность подстроить значение, взятое из архива.
+	Step                       *HSStep // шаг подстройки, nil - доля DefaultHSStepFraction ширины FD.
+	FuncEvaluations            int     // количество вычислений целевых функций.
+	Seed                       uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultMOHSConfig() *MOHSConfig {
+	return &MOHSConfig{
+		FD:                         DefaultHSFD,
+		MemorySize:                 DefaultMOHSMemorySize,
+		ProbToTakeFromMemory:       DefaultMOHSProbToTakeFromMemory,
+		ProbToApplyPitchAdjustment: DefaultMOHSProbToApplyPitchAdjustment,
+		FuncEvaluations:            DefaultMOHSFuncEvaluations,
+	}
+}
+
+// MOHSSolution решение из аппроксимации фронта Парето.
+type MOHSSolution struct {
+	X []float64
+	F []float64 // значения целевых функций.
+}
+
+// MOHSResult результат многокритериального гармонического поиска.
+type MOHSResult struct {
+	Front           []MOHSSolution // недоминируемые решения по возрастанию первой целевой функции.
+	FuncEvaluations int
+	Runtime         time.Duration
+	Status          optimize.Status
+}
+
+// MOHS многокритериальный гармонический поиск.
+//
+// Вместо отсортированной памяти хранится архив недоминируемых гармоний: импровизация попадает в архив,
+// если ее не доминирует ни одна гармония архива, и вытесняет доминируемые ей. При переполнении архива
+// удаляется гармония с наименьшим crowding distance, т.е. из самого плотного участка фронта.
+type MOHS struct {
+	conf *MOHSConfig
+
+	archive []*MOHSSolution
+	dim     int
+	step    functions.FuncDomain
+	rnd     *random.Generator
+}
+
+// Validate проверка настроек, которые иначе приведут к панике или ошибке во время поиска.
+func (c *MOHSConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.MemorySize <= 0 {
+		return fmt.Errorf("%w: MemorySize %d", ErrHSConfigMemorySize, c.MemorySize)
+	}
+
+	probs := []struct {
+		name string
+		p    float64
+	}{
+		{name: "ProbToTakeFromMemory", p: c.ProbToTakeFromMemory},
+		{name: "ProbToApplyPitchAdjustment", p: c.ProbToApplyPitchAdjustment},
+	}
+
+	for _, prob := range probs {
+		if !(0 <= prob.p && prob.p <= 1) {
+			return fmt.Errorf("%w: %s %v", ErrConfigProbability, prob.name, prob.p)
+		}
+	}
+
+	if c.Step != nil && c.Step.D == nil && !(c.Step.Fraction > 0) {
+		return fmt.Errorf("%w: Fraction %v", ErrHSConfigStep, c.Step.Fraction)
+	}
+
+	if c.FuncEvaluations < 0 {
+		return fmt.Errorf("%w: FuncEvaluations %d", ErrConfigNegative, c.FuncEvaluations)
+	}
+
+	return nil
+}
+
+// NewMOHS создать многокритериальный гармонический поиск. Настройки conf, по умолчанию DefaultMOHSConfig,
+// копируются.
+func NewMOHS(conf *MOHSConfig) (*MOHS, error) {
+	if conf == nil {
+		conf = DefaultMOHSConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &MOHS{conf: &c}, nil
+}
+
+func MustMOHS(conf *MOHSConfig) *MOHS {
+	m, err := NewMOHS(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Minimize поиск фронта Парето целевых функций f от стартовой точки initX.
+func (m *MOHS) Minimize(f MultiObjective, initX []float64) (*MOHSResult, error) {
+	if len(initX) != f.Dimension() {
+		return nil, fmt.Errorf("%w: %d, expected %d", ErrMOHSDimension, len(initX), f.Dimension())
+	}
+
+	started := time.Now()
+
+	m.archive = make([]*MOHSSolution, 0, m.conf.MemorySize+1)
+	m.dim = len(initX)
+	m.step = m.stepDomain()
+	m.rnd = random.NewGenerator(m.conf.Seed)
+
+	result := &MOHSResult{Status: optimize.FunctionEvaluationLimit}
+
+	x := make([]float64, m.dim)
+	for i, v := range initX {
+		d := m.conf.FD.VarDomain(i)
+		x[i] = d.Normalize(v)
+	}
+
+	for ; result.FuncEvaluations < m.conf.FuncEvaluations; result.FuncEvaluations++ {
+		s := &MOHSSolution{X: x, F: make([]float64, f.Objectives())}
+		f.Funcs(s.F, s.X)
+
+		if err := checkObjectivesNaN(s); err != nil {
+			result.Status = optimize.Failure
+			result.Runtime = time.Since(started)
+			result.Front = m.Front()
+
+			return result, err
+		}
+
+		m.add(s)
+
+		if result.FuncEvaluations+1 < m.conf.MemorySize {
+			x = m.randomHarmony()
+		} else {
+			x = m.improvisation()
+		}
+	}
+
+	result.Runtime = time.Since(started)
+	result.Front = m.Front()
+
+	return result, nil
+}
+
+// Front копии решений архива по возрастанию первой целевой функции.
+func (m *MOHS) Front() []MOHSSolution {
+	front := make([]MOHSSolution, len(m.archive))
+	for i, s := range m.archive {
+		front[i] = MOHSSolution{
+			X: append([]float64(nil), s.X...),
+			F: append([]float64(nil), s.F...),
+		}
+	}
+
+	sort.Slice(front, func(i, j int) bool {
+		return front[i].F[0] < front[j].F[0]
+	})
+
+	return front
+}
+
+// add добавление решения в архив, если его не доминирует ни одно решение архива.
+func (m *MOHS) add(s *MOHSSolution) {
+	kept := m.archive[:0]
+
+	for _, a := range m.archive {
+		if weaklyDominates(a.F, s.F) {
+			return
+		}
+
+		if !dominates(s.F, a.F) {
+			kept = append(kept, a)
+		}
+	}
+
+	m.archive = append(kept, s)
+
+	if len(m.archive) > m.conf.MemorySize {
+		m.truncate()
+	}
+}
+
+// truncate удаление решения с наименьшим crowding distance.
+func (m *MOHS) truncate() {
+	distances := crowdingDistances(m.archive)
+
+	worst := 0
+	for i, d := range distances {
+		if d < distances[worst] {
+			worst = i
+		}
+	}
+
+	m.archive = append(m.archive[:worst], m.archive[worst+1:]...)
+}
+
+func (m *MOHS) improvisation() []float64 {
+	rnd := m.rnd
+	improvised := make([]float64, m.dim)
+
+	for varIndex := range improvised {
+		if rnd.Float64() >= m.conf.ProbToTakeFromMemory {
+			improvised[varIndex] = rnd.ValueVarInDomain(varIndex, m.conf.FD)
+
+			continue
+		}
+
+		improvised[varIndex] = m.archive[rnd.Intn(len(m.archive))].X[varIndex]
+
+		if rnd.Float64() < m.conf.ProbToApplyPitchAdjustment {
+			d := m.conf.FD.VarDomain(varIndex)
+			improvised[varIndex] = d.Normalize(improvised[varIndex] + rnd.ValueVarInDomain(varIndex, m.step))
+		}
+	}
+
+	return improvised
+}
+
+func (m *MOHS) randomHarmony() []float64 {
+	x := make([]float64, m.dim)
+	for i := range x {
+		x[i] = m.rnd.ValueVarInDomain(i, m.conf.FD)
+	}
+
+	return x
+}
+
+// stepDomain область определения шага: MOHSConfig.Step или доля ширины FD.
+func (m *MOHS) stepDomain() functions.FuncDomain {
+	if m.conf.Step != nil {
+		return m.conf.Step.domain(m.dim, m.conf.FD)
+	}
+
+	return NewHSStepFraction(DefaultHSStepFraction).domain(m.dim, m.conf.FD)
+}
+
+func checkObjectivesNaN(s *MOHSSolution) error {
+	for _, f := range s.F {
+		if math.IsNaN(f) {
+			return fmt.Errorf("%w at %v", ErrHSNaN, s.X)
+		}
+	}
+
+	return nil
+}
+
+// dominates l не хуже r по всем целевым функциям и лучше хотя бы по одной.
+func dominates(l, r []float64) bool {
+	better := false
+
+	for i := range l {
+		if l[i] > r[i] {
+			return false
+		}
+
+		if l[i] < r[i] {
+			better = true
+		}
+	}
+
+	return better
+}
+
+// weaklyDominates l не хуже r по всем целевым функциям.
+func weaklyDominates(l, r []float64) bool {
+	for i := range l {
+		if l[i] > r[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// crowdingDistances crowding distance решений: сумма по целевым функциям нормированных расстояний
+// между соседями решения. У крайних решений расстояние бесконечное.
+func crowdingDistances(solutions []*MOHSSolution) []float64 {
+	n := len(solutions)
+	distances := make([]float64, n)
+
+	if n == 0 {
+		return distances
+	}
+
+	order := make([]int, n)
+
+	for objective := range solutions[0].F {
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.Slice(order, func(i, j int) bool {
+			return solutions[order[i]].F[objective] < solutions[order[j]].F[objective]
+		})
+
+		lowest := solutions[order[0]].F[objective]
+		highest := solutions[order[n-1]].F[objective]
+
+		distances[order[0]] = math.Inf(1)
+		distances[order[n-1]] = math.Inf(1)
+
+		if highest == lowest {
+			continue
+		}
+
+		for i := 1; i < n-1; i++ {
+			gap := solutions[order[i+1]].F[objective] - solutions[order[i-1]].F[objective]
+			distances[order[i]] += gap / (highest - lowest)
+		}
+	}
+
+	return distances
+}
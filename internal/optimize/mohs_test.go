@@ -0,0 +1,183 @@
+package optimize_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestMOHS_Minimize(t *testing.T) {
+	const tolerance = 1e-1
+
+	// front точка фронта Парето при t из [0, 1].
+	tests := []struct {
+		name       string
+		objectives []string
+		initX      []float64
+		front      func(t float64) []float64
+	}{
+		{
+			name:       "Schaffer",
+			objectives: []string{"x ** 2", "(x - 2) ** 2"},
+			initX:      []float64{10},
+			front: func(t float64) []float64 {
+				x := 2 * t
+
+				return []float64{x * x, (x - 2) * (x - 2)}
+			},
+		},
+		{
+			name:       "TwoSpheres",
+			objectives: []string{"x ** 2 + y ** 2", "(x - 1) ** 2 + (y - 1) ** 2"},
+			initX:      []float64{-5, 5},
+			front: func(t float64) []float64 {
+				return []float64{2 * t * t, 2 * (t - 1) * (t - 1)}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultMOHSConfig()
+			conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+			conf.MemorySize = 30
+			conf.Seed = 1
+
+			f := functions.MustMultiExpression(test.objectives...)
+
+			result, err := internaloptimize.MustMOHS(conf).Minimize(f, test.initX)
+			asserting.NoError(err)
+			asserting.Equal(optimize.FunctionEvaluationLimit, result.Status)
+			asserting.Equal(conf.FuncEvaluations, result.FuncEvaluations)
+			asserting.True(len(result.Front) > 1 && len(result.Front) <= conf.MemorySize)
+
+			values := make([]float64, f.Objectives())
+
+			for i, s := range result.Front {
+				f.Funcs(values, s.X)
+				asserting.Equal(values, s.F, "solution values must match its point")
+				asserting.LessOrEqual(frontDistance(s.F, test.front), tolerance, "solution must be near the Pareto front")
+
+				if i == 0 {
+					continue
+				}
+
+				prev := result.Front[i-1]
+				asserting.LessOrEqual(prev.F[0], s.F[0], "front must be sorted by the first objective")
+
+				for _, other := range result.Front[:i] {
+					asserting.False(dominatesAll(other.F, s.F) || dominatesAll(s.F, other.F),
+						"front solutions must not dominate each other")
+				}
+			}
+		})
+	}
+}
+
+func TestMOHS_Errors(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultMOHSConfig()
+
+	_, err := internaloptimize.MustMOHS(conf).Minimize(functions.MustMultiExpression("x", "y"), []float64{1})
+	asserting.True(errors.Is(err, internaloptimize.ErrMOHSDimension))
+
+	result, err := internaloptimize.MustMOHS(conf).Minimize(functions.MustMultiExpression("x", "(x - 20) ** 0.5"),
+		[]float64{1})
+	asserting.Error(err)
+	asserting.Equal(optimize.Failure, result.Status)
+}
+
+func TestMOHSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.MOHSConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.MOHSConfig) {}},
+		{name: "Nil"},
+		{
+			name:   "NoFD",
+			modify: func(c *internaloptimize.MOHSConfig) { c.FD = nil },
+			err:    internaloptimize.ErrConfigFD,
+		},
+		{
+			name:   "ZeroMemorySize",
+			modify: func(c *internaloptimize.MOHSConfig) { c.MemorySize = 0 },
+			err:    internaloptimize.ErrHSConfigMemorySize,
+		},
+		{
+			name:   "ProbToTakeFromMemory",
+			modify: func(c *internaloptimize.MOHSConfig) { c.ProbToTakeFromMemory = 1.5 },
+			err:    internaloptimize.ErrConfigProbability,
+		},
+		{
+			name:   "ProbToApplyPitchAdjustment",
+			modify: func(c *internaloptimize.MOHSConfig) { c.ProbToApplyPitchAdjustment = -0.1 },
+			err:    internaloptimize.ErrConfigProbability,
+		},
+		{
+			name:   "Step",
+			modify: func(c *internaloptimize.MOHSConfig) { c.Step = &internaloptimize.HSStep{} },
+			err:    internaloptimize.ErrHSConfigStep,
+		},
+		{
+			name:   "NegativeEvaluations",
+			modify: func(c *internaloptimize.MOHSConfig) { c.FuncEvaluations = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			var conf *internaloptimize.MOHSConfig
+			if test.modify != nil {
+				conf = internaloptimize.DefaultMOHSConfig()
+				test.modify(conf)
+			}
+
+			_, err := internaloptimize.NewMOHS(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
+
+// frontDistance расстояние от значений f до фронта Парето front.
+func frontDistance(f []float64, front func(t float64) []float64) float64 {
+	const points = 10_000
+
+	distance := math.Inf(1)
+
+	for i := 0; i <= points; i++ {
+		distance = math.Min(distance, floats.Distance(f, front(float64(i)/points), 2))
+	}
+
+	return distance
+}
+
+// dominatesAll l не хуже r по всем целевым функциям.
+func dominatesAll(l, r []float64) bool {
+	for i := range l {
+		if l[i] > r[i] {
+			return false
+		}
+	}
+
+	return true
+}
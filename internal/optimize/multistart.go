@@ -0,0 +1,307 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultMultiStartFuncEvaluations  = 20_000
+	DefaultMultiStartStartEvaluations = 2000
+	DefaultMultiStartPopulationGrowth = 1
+	DefaultMultiStartDistinct         = 1e-3
+
+	// DefaultIPOPPopulationGrowth рост популяции с каждым рестартом в IPOP (Auger, Hansen).
+	DefaultIPOPPopulationGrowth = 2
+)
+
+var (
+	ErrMultiStartMethod = errors.New("multi-start method factory is not set")
+	ErrMultiStartBudget = errors.New("evaluation budget must be positive")
+	ErrMultiStartDesign = errors.New("unknown multi-start design")
+	ErrMultiStartGrowth = errors.New("population growth must not be less than 1")
+	ErrMultiStartNoRuns = errors.New("no start has finished with a result")
+)
+
+// MultiStartDesign способ выбора стартовых точек.
+type MultiStartDesign int
+
+const (
+	// MultiStartRandom равномерно распределенные случайные точки FD.
+	MultiStartRandom MultiStartDesign = iota
+	// MultiStartSobol квазислучайная последовательность Соболя, не больше SobolMaxDimension переменных.
+	MultiStartSobol
+)
+
+// MultiStartRun параметры одного старта для создания метода.
+type MultiStartRun struct {
+	Index int     // номер старта от 0.
+	Scale float64 // множитель размера популяции или памяти, PopulationGrowth^Index.
+	Seed  uint64  // зерно генератора случайных чисел метода, зависит только от MultiStartConfig.Seed и Index.
+}
+
+// MultiStartConfig настройки многократного запуска.
+type MultiStartConfig struct {
+	FD functions.FuncDomain
+	// Method новый метод для старта run. Популяционные методы для рестартов IPOP умножают
+	// размер популяции или памяти на run.Scale. Локальные методы gonum не знают FD, их стоит обернуть в Bounded.
+	Method func(run MultiStartRun) optimize.Method `json:"-"`
+	// Converger новый критерий остановки для каждого старта, nil - критерий optimize.Minimize по умолчанию.
+	Converger        func() optimize.Converger `json:"-"`
+	Design           MultiStartDesign
+	FuncEvaluations  int     // общий бюджет вычислений функции всех стартов.
+	StartEvaluations int     // наибольший бюджет вычислений одного старта.
+	Starts           int     // наибольшее количество стартов, 0 - пока не исчерпан FuncEvaluations.
+	Concurrent       int     // одновременных стартов, 0 - runtime.GOMAXPROCS.
+	PopulationGrowth float64 // рост run.Scale с каждым стартом, 1 - без роста.
+	Distinct         float64 // доля ширины FD, ближе которой по всем переменным оптимумы совпадают.
+	Seed             uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultMultiStartConfig() *MultiStartConfig {
+	return &MultiStartConfig{
+		FD:               DefaultHSFD,
+		Design:           MultiStartRandom,
+		FuncEvaluations:  DefaultMultiStartFuncEvaluations,
+		StartEvaluations: DefaultMultiStartStartEvaluations,
+		PopulationGrowth: DefaultMultiStartPopulationGrowth,
+		Distinct:         DefaultMultiStartDistinct,
+	}
+}
+
+// DefaultIPOPConfig настройки рестартов с удвоением популяции.
+func DefaultIPOPConfig() *MultiStartConfig {
+	conf := DefaultMultiStartConfig()
+	conf.PopulationGrowth = DefaultIPOPPopulationGrowth
+
+	return conf
+}
+
+func (c *MultiStartConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.Method == nil {
+		return ErrMultiStartMethod
+	}
+
+	if c.Design < MultiStartRandom || c.Design > MultiStartSobol {
+		return fmt.Errorf("%w: %d", ErrMultiStartDesign, c.Design)
+	}
+
+	if c.FuncEvaluations < 1 || c.StartEvaluations < 1 {
+		return fmt.Errorf("%w: FuncEvaluations %d, StartEvaluations %d",
+			ErrMultiStartBudget, c.FuncEvaluations, c.StartEvaluations)
+	}
+
+	if c.Starts < 0 || c.Concurrent < 0 {
+		return fmt.Errorf("%w: Starts %d, Concurrent %d", ErrConfigNegative, c.Starts, c.Concurrent)
+	}
+
+	if !(c.PopulationGrowth >= 1) {
+		return fmt.Errorf("%w: %v", ErrMultiStartGrowth, c.PopulationGrowth)
+	}
+
+	if !(c.Distinct >= 0) {
+		return fmt.Errorf("%w: Distinct %v", ErrConfigNegative, c.Distinct)
+	}
+
+	return nil
+}
+
+// MultiStartOptimum оптимум, к которому сошлись один или несколько стартов.
+type MultiStartOptimum struct {
+	X    []float64
+	F    float64
+	Runs int // стартов, нашедших этот оптимум.
+}
+
+// MultiStartResult итоги многократного запуска.
+type MultiStartResult struct {
+	Optima          []MultiStartOptimum // различные оптимумы по возрастанию F.
+	Runs            int                 // завершенных стартов.
+	FuncEvaluations int                 // вычислений функции всех стартов.
+}
+
+// MultiStart многократный запуск метода из разных стартовых точек с общим бюджетом вычислений.
+type MultiStart struct {
+	conf *MultiStartConfig
+}
+
+// multiStartRun итоги одного старта.
+type multiStartRun struct {
+	budget int
+	result *optimize.Result
+	err    error
+}
+
+// NewMultiStart создать многократный запуск. Настройки conf, по умолчанию DefaultMultiStartConfig, копируются.
+func NewMultiStart(conf *MultiStartConfig) (*MultiStart, error) {
+	if conf == nil {
+		conf = DefaultMultiStartConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &MultiStart{conf: &c}, nil
+}
+
+func MustMultiStart(conf *MultiStartConfig) *MultiStart {
+	m, err := NewMultiStart(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Minimize запуски метода на задаче p, первый - из точки x, остальные - из точек Design.
+//
+// Каждый старт получает не больше StartEvaluations вычислений из общего бюджета, неизрасходованные
+// вычисления возвращаются в бюджет после его завершения. Вернет ошибку, только если ни один старт
+// не закончился результатом.
+func (m *MultiStart) Minimize(p optimize.Problem, x []float64) (*MultiStartResult, error) {
+	c := m.conf
+	dim := len(x)
+
+	if c.Design == MultiStartSobol && dim > SobolMaxDimension {
+		return nil, fmt.Errorf("%w: %d > %d", ErrSearchSobolDimension, dim, SobolMaxDimension)
+	}
+
+	concurrent := c.Concurrent
+	if concurrent == 0 {
+		concurrent = runtime.GOMAXPROCS(0)
+	}
+
+	rnd := random.NewGenerator(c.Seed)
+	sobol := newSobolSequence(dim)
+	runs := make(chan multiStartRun)
+
+	var (
+		remaining = c.FuncEvaluations
+		index     int
+		active    int
+		finished  []*optimize.Result
+		lastErr   error
+		res       = &MultiStartResult{}
+	)
+
+	for {
+		for active < concurrent && remaining > 0 && (c.Starts == 0 || index < c.Starts) {
+			budget := c.StartEvaluations
+			if budget > remaining {
+				budget = remaining
+			}
+
+			remaining -= budget
+
+			var start []float64
+
+			switch {
+			case index == 0:
+				start = normalizePoint(append([]float64(nil), x...), c.FD)
+			case c.Design == MultiStartSobol:
+				start = sobol.next(c.FD)
+			default:
+				start = randomPoint(rnd, dim, c.FD)
+			}
+
+			run := MultiStartRun{
+				Index: index,
+				Scale: math.Pow(c.PopulationGrowth, float64(index)),
+				Seed:  rnd.Uint64(),
+			}
+
+			go func(method optimize.Method, start []float64, budget int) {
+				settings := &optimize.Settings{FuncEvaluations: budget}
+				if c.Converger != nil {
+					settings.Converger = c.Converger()
+				}
+
+				result, err := optimize.Minimize(p, start, settings, method)
+				runs <- multiStartRun{budget: budget, result: result, err: err}
+			}(c.Method(run), start, budget)
+
+			index++
+			active++
+		}
+
+		if active == 0 {
+			break
+		}
+
+		r := <-runs
+		active--
+
+		if r.result == nil {
+			lastErr = r.err
+
+			continue
+		}
+
+		res.Runs++
+		res.FuncEvaluations += r.result.FuncEvaluations
+		remaining += r.budget - r.result.FuncEvaluations
+		finished = append(finished, r.result)
+	}
+
+	if len(finished) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrMultiStartNoRuns, lastErr)
+	}
+
+	res.Optima = m.distinct(finished)
+
+	return res, nil
+}
+
+// distinct различные оптимумы результатов по возрастанию F. Результат, совпавший с лучшим
+// по Distinct, считается тем же оптимумом.
+func (m *MultiStart) distinct(results []*optimize.Result) []MultiStartOptimum {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].F < results[j].F
+	})
+
+	var optima []MultiStartOptimum
+
+	for _, r := range results {
+		merged := false
+
+		for i := range optima {
+			if m.same(optima[i].X, r.X) {
+				optima[i].Runs++
+				merged = true
+
+				break
+			}
+		}
+
+		if !merged {
+			optima = append(optima, MultiStartOptimum{X: r.X, F: r.F, Runs: 1})
+		}
+	}
+
+	return optima
+}
+
+// same совпадают ли точки a и b с точностью Distinct.
+func (m *MultiStart) same(a, b []float64) bool {
+	for j := range a {
+		d := m.conf.FD.VarDomain(j)
+		if math.Abs(a[j]-b[j]) > m.conf.Distinct*(d.Top-d.Bottom) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,175 @@
+package optimize_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestMultiStart_Minimize(t *testing.T) {
+	designs := []struct {
+		name   string
+		design internaloptimize.MultiStartDesign
+	}{
+		{name: "Random", design: internaloptimize.MultiStartRandom},
+		{name: "Sobol", design: internaloptimize.MultiStartSobol},
+	}
+
+	for _, design := range designs {
+		t.Run(design.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			fd := functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+			bounded := internaloptimize.DefaultBoundedConfig()
+			bounded.FD = fd
+
+			conf := internaloptimize.DefaultMultiStartConfig()
+			conf.FD = fd
+			conf.Design = design.design
+			conf.StartEvaluations = 500
+			conf.Concurrent = 4
+			conf.Seed = 3
+			conf.Method = func(_ internaloptimize.MultiStartRun) optimize.Method {
+				return internaloptimize.MustBounded(&optimize.NelderMead{}, bounded)
+			}
+
+			// у Levi13 много локальных минимумов, NelderMead из одной точки застревает в ближайшем.
+			prob := functions.MustProblem(functions.Levi13, nil, nil)
+
+			result, err := internaloptimize.MustMultiStart(conf).Minimize(prob, []float64{9, 9})
+			asserting.NoError(err)
+			asserting.LessOrEqual(result.FuncEvaluations, conf.FuncEvaluations)
+			asserting.Greater(len(result.Optima), 1)
+
+			asserting.InDelta(0, result.Optima[0].F, 1e-6)
+			asserting.InDelta(1, result.Optima[0].X[0], 1e-3)
+			asserting.InDelta(1, result.Optima[0].X[1], 1e-3)
+
+			runs := 0
+			for i, optimum := range result.Optima {
+				runs += optimum.Runs
+
+				if i > 0 {
+					asserting.LessOrEqual(result.Optima[i-1].F, optimum.F)
+				}
+			}
+
+			asserting.Equal(result.Runs, runs)
+		})
+	}
+}
+
+func TestMultiStart_IPOP(t *testing.T) {
+	asserting := assert.New(t)
+
+	fd := functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+
+	var (
+		mu     sync.Mutex
+		scales = map[int]float64{}
+	)
+
+	conf := internaloptimize.DefaultIPOPConfig()
+	conf.FD = fd
+	conf.Starts = 4
+	conf.FuncEvaluations = 80_000
+	conf.StartEvaluations = 20_000
+	conf.Seed = 3
+	conf.Converger = func() optimize.Converger { return optimize.NeverTerminate{} }
+	conf.Method = func(run internaloptimize.MultiStartRun) optimize.Method {
+		mu.Lock()
+		scales[run.Index] = run.Scale
+		mu.Unlock()
+
+		size := int(float64(internaloptimize.DefaultHSMemorySize) * run.Scale)
+
+		return internaloptimize.MustHS(nil,
+			internaloptimize.WithHSFD(fd),
+			internaloptimize.WithHSMemorySize(size),
+			internaloptimize.WithHSSeed(run.Seed),
+		)
+	}
+
+	prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+
+	result, err := internaloptimize.MustMultiStart(conf).Minimize(prob, []float64{9, 9})
+	asserting.NoError(err)
+	asserting.Equal(4, result.Runs)
+	asserting.Equal(map[int]float64{0: 1, 1: 2, 2: 4, 3: 8}, scales)
+	asserting.InDelta(0, result.Optima[0].F, 1e-3)
+}
+
+func TestMultiStart_SobolDimension(t *testing.T) {
+	conf := internaloptimize.DefaultMultiStartConfig()
+	conf.Design = internaloptimize.MultiStartSobol
+	conf.Method = func(_ internaloptimize.MultiStartRun) optimize.Method { return &optimize.NelderMead{} }
+
+	prob := optimize.Problem{Func: func(x []float64) float64 { return 0 }}
+
+	_, err := internaloptimize.MustMultiStart(conf).Minimize(prob, make([]float64, internaloptimize.SobolMaxDimension+1))
+	assert.True(t, errors.Is(err, internaloptimize.ErrSearchSobolDimension), "unexpected error %v", err)
+}
+
+func TestMultiStartConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.MultiStartConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.MultiStartConfig) {}},
+		{
+			name:   "NilFD",
+			modify: func(c *internaloptimize.MultiStartConfig) { c.FD = nil },
+			err:    internaloptimize.ErrConfigFD,
+		},
+		{
+			name:   "NilMethod",
+			modify: func(c *internaloptimize.MultiStartConfig) { c.Method = nil },
+			err:    internaloptimize.ErrMultiStartMethod,
+		},
+		{
+			name:   "UnknownDesign",
+			modify: func(c *internaloptimize.MultiStartConfig) { c.Design = 10 },
+			err:    internaloptimize.ErrMultiStartDesign,
+		},
+		{
+			name:   "ZeroBudget",
+			modify: func(c *internaloptimize.MultiStartConfig) { c.StartEvaluations = 0 },
+			err:    internaloptimize.ErrMultiStartBudget,
+		},
+		{
+			name:   "NegativeStarts",
+			modify: func(c *internaloptimize.MultiStartConfig) { c.Starts = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name:   "ShrinkingPopulation",
+			modify: func(c *internaloptimize.MultiStartConfig) { c.PopulationGrowth = 0.5 },
+			err:    internaloptimize.ErrMultiStartGrowth,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultMultiStartConfig()
+			conf.Method = func(_ internaloptimize.MultiStartRun) optimize.Method { return &optimize.NelderMead{} }
+			test.modify(conf)
+
+			_, err := internaloptimize.NewMultiStart(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultPatternInitialStep = 0.1
+	DefaultPatternMinStep     = 1e-8
+	DefaultPatternContraction = 0.5
+	DefaultPatternExpansion   = 1
+
+	// patternMeshFactor во сколько раз меняется размер сетки MADS после шага.
+	patternMeshFactor = 4
+)
+
+var (
+	ErrPatternMode        = errors.New("unknown pattern search mode")
+	ErrPatternStep        = errors.New("step must be in (0, 1]")
+	ErrPatternContraction = errors.New("contraction must be in (0, 1)")
+	ErrPatternExpansion   = errors.New("expansion must not be less than 1")
+	ErrPatternConverged   = errors.New("step fell below the minimum")
+)
+
+// PatternStepTolerance шаг поиска по образцу стал меньше PatternConfig.MinStep.
+var PatternStepTolerance = optimize.NewStatus("PatternStepTolerance", false, ErrPatternConverged)
+
+// PatternMode метод прямого поиска.
+type PatternMode int
+
+const (
+	// PatternHookeJeeves исследующий поиск по координатам и ускоряющий шаг по образцу (Hooke, Jeeves).
+	// Исследуются обе точки координаты одновременно, координаты - по очереди.
+	PatternHookeJeeves PatternMode = iota
+	// PatternCompass опрос всех 2n точек x +- шаг вдоль координат, переход в лучшую, если она лучше x,
+	// иначе сжатие шага.
+	PatternCompass
+	// PatternMADS опрос 2n точек вдоль столбцов случайной ортогональной матрицы Хаусхолдера на сетке,
+	// которая мельчает быстрее шага опроса (OrthoMADS, Abramson, Audet, Dennis, Le Digabel).
+	PatternMADS
+)
+
+// PatternConfig настройки поиска по образцу. Шаги задаются в долях ширины области определения переменной.
+type PatternConfig struct {
+	FD          functions.FuncDomain
+	Mode        PatternMode
+	InitialStep float64 // начальный шаг.
+	MinStep     float64 // шаг, после уменьшения ниже которого поиск завершается с PatternStepTolerance.
+	Contraction float64 // множитель шага после неудачного опроса для PatternHookeJeeves и PatternCompass.
+	Expansion   float64 // множитель шага после удачного опроса для PatternCompass.
+	Seed        uint64  // зерно генератора случайных чисел для PatternMADS, 0 - случайное.
+}
+
+func DefaultPatternConfig() *PatternConfig {
+	return &PatternConfig{
+		FD:          DefaultHSFD,
+		Mode:        PatternHookeJeeves,
+		InitialStep: DefaultPatternInitialStep,
+		MinStep:     DefaultPatternMinStep,
+		Contraction: DefaultPatternContraction,
+		Expansion:   DefaultPatternExpansion,
+	}
+}
+
+func (c *PatternConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.Mode < PatternHookeJeeves || c.Mode > PatternMADS {
+		return fmt.Errorf("%w: %d", ErrPatternMode, c.Mode)
+	}
+
+	if !(0 < c.InitialStep && c.InitialStep <= 1) {
+		return fmt.Errorf("%w: InitialStep %v", ErrPatternStep, c.InitialStep)
+	}
+
+	if !(0 < c.MinStep && c.MinStep <= 1) {
+		return fmt.Errorf("%w: MinStep %v", ErrPatternStep, c.MinStep)
+	}
+
+	if !(0 < c.Contraction && c.Contraction < 1) {
+		return fmt.Errorf("%w: %v", ErrPatternContraction, c.Contraction)
+	}
+
+	if !(c.Expansion >= 1) {
+		return fmt.Errorf("%w: %v", ErrPatternExpansion, c.Expansion)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*Pattern)(nil)
+
+// Pattern прямой поиск по образцу без производных. Точки опроса, вышедшие за FD, останавливаются
+// на ее границе, точки одного опроса вычисляются одновременно в optimize.Settings.Concurrent задачах.
+type Pattern struct {
+	populationMethod
+	conf  *PatternConfig
+	state *patternState
+}
+
+// patternPhase шаг поиска по образцу.
+type patternPhase int
+
+const (
+	patternInit    patternPhase = iota // вычисление стартовой точки.
+	patternPoll                        // опрос точек вокруг center.
+	patternForward                     // вычисление точки шага по образцу Хука-Дживса.
+)
+
+type patternState struct {
+	dim   int
+	rnd   *random.Generator
+	phase patternPhase
+	step  float64 // шаг опроса.
+	mesh  float64 // размер сетки MADS.
+
+	base   memoryComponent // лучшая точка.
+	center memoryComponent // точка, вокруг которой идет исследующий поиск Хука-Дживса.
+	coord  int             // исследуемая координата Хука-Дживса.
+	ahead  bool            // исследуется точка шага по образцу.
+}
+
+// NewPattern создать экземпляр поиска по образцу. Настройки conf, по умолчанию DefaultPatternConfig, копируются.
+func NewPattern(conf *PatternConfig) (*Pattern, error) {
+	if conf == nil {
+		conf = DefaultPatternConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Pattern{conf: &c}, nil
+}
+
+func MustPattern(conf *PatternConfig) *Pattern {
+	p, err := NewPattern(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func (p *Pattern) Init(dim, tasks int) int {
+	p.state = &patternState{
+		dim: dim,
+		rnd: random.NewGenerator(p.conf.Seed),
+	}
+
+	return p.init(p, tasks)
+}
+
+func (p *Pattern) start(x []float64) {
+	s := p.state
+	s.phase = patternInit
+	s.step = p.conf.InitialStep
+	s.mesh = p.conf.InitialStep
+	s.base = memoryComponent{X: normalizePoint(x, p.conf.FD)}
+	s.coord = 0
+	s.ahead = false
+}
+
+func (p *Pattern) ask() [][]float64 {
+	s := p.state
+
+	switch s.phase {
+	case patternInit:
+		return [][]float64{s.base.X}
+	case patternForward:
+		return [][]float64{s.center.X}
+	}
+
+	switch p.conf.Mode {
+	case PatternHookeJeeves:
+		return [][]float64{p.move(s.center.X, s.coord, s.step), p.move(s.center.X, s.coord, -s.step)}
+	case PatternCompass:
+		xs := make([][]float64, 0, 2*s.dim) //nolint
+		for j := 0; j < s.dim; j++ {
+			xs = append(xs, p.move(s.base.X, j, s.step), p.move(s.base.X, j, -s.step))
+		}
+
+		return xs
+	default:
+		return p.meshPoll()
+	}
+}
+
+func (p *Pattern) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := p.state
+
+	switch s.phase {
+	case patternInit:
+		s.base.F = fs[0]
+		s.center = memoryComponent{F: fs[0], X: append([]float64(nil), s.base.X...)}
+		s.phase = patternPoll
+
+		return optimize.NotTerminated, nil
+	case patternForward:
+		s.center.F = fs[0]
+		s.phase = patternPoll
+
+		return optimize.NotTerminated, nil
+	}
+
+	i := bestIndex(fs)
+
+	if p.conf.Mode == PatternHookeJeeves {
+		if fs[i] < s.center.F {
+			s.center = memoryComponent{F: fs[i], X: append([]float64(nil), xs[i]...)}
+		}
+
+		if s.coord++; s.coord < s.dim {
+			return optimize.NotTerminated, nil
+		}
+
+		s.coord = 0
+
+		return p.explored(), nil
+	}
+
+	if fs[i] < s.base.F {
+		s.base = memoryComponent{F: fs[i], X: append([]float64(nil), xs[i]...)}
+
+		if p.conf.Mode == PatternMADS {
+			s.mesh = math.Min(p.conf.InitialStep, s.mesh*patternMeshFactor)
+			s.step = p.conf.InitialStep * math.Sqrt(s.mesh/p.conf.InitialStep)
+		} else {
+			s.step = math.Min(1, s.step*p.conf.Expansion)
+		}
+
+		return optimize.NotTerminated, nil
+	}
+
+	return p.contract(), nil
+}
+
+// explored завершение исследующего поиска Хука-Дживса: шаг по образцу после успеха,
+// иначе возврат к лучшей точке или сжатие шага.
+func (p *Pattern) explored() optimize.Status {
+	s := p.state
+
+	if s.center.F < s.base.F {
+		previous := s.base.X
+		s.ahead = false
+		s.base = memoryComponent{F: s.center.F, X: append([]float64(nil), s.center.X...)}
+
+		forward := make([]float64, s.dim)
+		for j := range forward {
+			d := p.conf.FD.VarDomain(j)
+			forward[j] = d.Normalize(2*s.base.X[j] - previous[j]) //nolint
+		}
+
+		if !floats.Equal(forward, s.base.X) {
+			s.center = memoryComponent{X: forward}
+			s.phase = patternForward
+			s.ahead = true
+		}
+
+		return optimize.NotTerminated
+	}
+
+	s.center = memoryComponent{F: s.base.F, X: append([]float64(nil), s.base.X...)}
+
+	// неудачный поиск около точки шага по образцу повторяется около лучшей точки с тем же шагом.
+	if s.ahead {
+		s.ahead = false
+
+		return optimize.NotTerminated
+	}
+
+	return p.contract()
+}
+
+// contract уменьшение шага после неудачного опроса.
+func (p *Pattern) contract() optimize.Status {
+	s := p.state
+
+	if p.conf.Mode == PatternMADS {
+		s.mesh /= patternMeshFactor
+		s.step = p.conf.InitialStep * math.Sqrt(s.mesh/p.conf.InitialStep)
+	} else {
+		s.step *= p.conf.Contraction
+	}
+
+	if s.step < p.conf.MinStep {
+		return PatternStepTolerance
+	}
+
+	return optimize.NotTerminated
+}
+
+// move точка x, сдвинутая по координате j на step ширин области определения.
+func (p *Pattern) move(x []float64, j int, step float64) []float64 {
+	y := append([]float64(nil), x...)
+	d := p.conf.FD.VarDomain(j)
+	y[j] = d.Normalize(y[j] + step*(d.Top-d.Bottom))
+
+	return y
+}
+
+// meshPoll точки опроса MADS: x +- столбцы матрицы Хаусхолдера H = I - 2*v*v^T случайного единичного
+// вектора v длиной step, округленные до узлов сетки размера mesh.
+func (p *Pattern) meshPoll() [][]float64 {
+	s := p.state
+
+	v := make([]float64, s.dim)
+	for j := range v {
+		v[j] = s.rnd.NormFloat64()
+	}
+
+	if norm := floats.Norm(v, 2); norm > 0 { //nolint
+		floats.Scale(1/norm, v)
+	} else {
+		v[0] = 1
+	}
+
+	xs := make([][]float64, 0, 2*s.dim) //nolint
+
+	for k := 0; k < s.dim; k++ {
+		for _, sign := range []float64{1, -1} {
+			x := append([]float64(nil), s.base.X...)
+
+			for j := range x {
+				h := -2 * v[j] * v[k] //nolint
+				if j == k {
+					h++
+				}
+
+				d := p.conf.FD.VarDomain(j)
+				x[j] = d.Normalize(x[j] + s.mesh*math.Round(sign*s.step*h/s.mesh)*(d.Top-d.Bottom))
+			}
+
+			xs = append(xs, x)
+		}
+	}
+
+	return xs
+}
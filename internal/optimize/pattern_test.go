@@ -0,0 +1,152 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestPattern_Run(t *testing.T) {
+	modes := []struct {
+		name string
+		mode internaloptimize.PatternMode
+	}{
+		{name: "HookeJeeves", mode: internaloptimize.PatternHookeJeeves},
+		{name: "Compass", mode: internaloptimize.PatternCompass},
+		{name: "MADS", mode: internaloptimize.PatternMADS},
+	}
+	// локальные методы сходятся к ближайшему минимуму, поэтому задачи гладкие или с единственным минимумом.
+	problems := []struct {
+		name    string
+		exp     string
+		start   []float64
+		minimum float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, start: []float64{4, 4}, minimum: 0},
+		{name: "Matias", exp: functions.Matias, start: []float64{9, 9}, minimum: 0},
+		{name: "Rosenbrock", exp: "(1 - x1)**2 + 100 * (x2 - x1**2)**2", start: []float64{-1.2, 1}, minimum: 0},
+	}
+
+	for _, mode := range modes {
+		for _, problem := range problems {
+			t.Run(mode.name+"/"+problem.name, func(t *testing.T) {
+				asserting := assert.New(t)
+
+				d := functions.VarDomain{Bottom: -10, Top: 10}
+				conf := internaloptimize.DefaultPatternConfig()
+				conf.FD = functions.NewSingleFuncDomain(d)
+				conf.Mode = mode.mode
+				conf.Seed = 3
+
+				prob := functions.MustProblem(problem.exp, nil, nil)
+				settings := &optimize.Settings{
+					Converger:       optimize.NeverTerminate{},
+					FuncEvaluations: 100_000,
+					Concurrent:      4,
+				}
+
+				result, err := optimize.Minimize(prob, problem.start, settings, internaloptimize.MustPattern(conf))
+				asserting.NoError(err)
+				asserting.Equal(internaloptimize.PatternStepTolerance, result.Status)
+				asserting.InDelta(problem.minimum, result.F, 1e-6)
+
+				for _, v := range result.X {
+					asserting.NoError(d.Validate(v))
+				}
+			})
+		}
+	}
+}
+
+func TestPattern_Bounds(t *testing.T) {
+	// безусловный минимум (5, 5) вне FD, поиск останавливается на углу (1, 1).
+	for _, mode := range []internaloptimize.PatternMode{
+		internaloptimize.PatternHookeJeeves,
+		internaloptimize.PatternCompass,
+		internaloptimize.PatternMADS,
+	} {
+		asserting := assert.New(t)
+
+		d := functions.VarDomain{Bottom: -1, Top: 1}
+		conf := internaloptimize.DefaultPatternConfig()
+		conf.FD = functions.NewSingleFuncDomain(d)
+		conf.Mode = mode
+
+		outside := 0
+		prob := optimize.Problem{
+			Func: func(x []float64) float64 {
+				for _, v := range x {
+					if d.Validate(v) != nil {
+						outside++
+					}
+				}
+
+				return (x[0]-5)*(x[0]-5) + (x[1]-5)*(x[1]-5)
+			},
+		}
+
+		result, err := optimize.Minimize(prob, []float64{0, 0}, nil, internaloptimize.MustPattern(conf))
+		asserting.NoError(err)
+		asserting.Zero(outside)
+		asserting.InDelta(1, result.X[0], 1e-6)
+		asserting.InDelta(1, result.X[1], 1e-6)
+	}
+}
+
+func TestPatternConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.PatternConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.PatternConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.PatternConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "UnknownMode",
+			modify: func(c *internaloptimize.PatternConfig) { c.Mode = 10 },
+			err:    internaloptimize.ErrPatternMode,
+		},
+		{
+			name:   "ZeroInitialStep",
+			modify: func(c *internaloptimize.PatternConfig) { c.InitialStep = 0 },
+			err:    internaloptimize.ErrPatternStep,
+		},
+		{
+			name:   "MinStepAboveOne",
+			modify: func(c *internaloptimize.PatternConfig) { c.MinStep = 2 },
+			err:    internaloptimize.ErrPatternStep,
+		},
+		{
+			name:   "NoContraction",
+			modify: func(c *internaloptimize.PatternConfig) { c.Contraction = 1 },
+			err:    internaloptimize.ErrPatternContraction,
+		},
+		{
+			name:   "ShrinkingExpansion",
+			modify: func(c *internaloptimize.PatternConfig) { c.Expansion = 0.5 },
+			err:    internaloptimize.ErrPatternExpansion,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultPatternConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewPattern(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+var (
+	ErrNaN                  = errors.New("objective function returned NaN")
+	ErrConfigFD             = errors.New("function domain is not set")
+	ErrConfigProbability    = errors.New("probability must be in [0, 1]")
+	ErrConfigNegative       = errors.New("value must not be negative")
+	ErrConfigPopulationSize = errors.New("population size is too small")
+)
+
+// generational метод, который предлагает точки поколениями и получает их значения.
+type generational interface {
+	// start начало поиска от стартовой точки x.
+	start(x []float64)
+	// ask точки очередного поколения для вычисления.
+	ask() [][]float64
+	// tell значения fs точек поколения xs.
+	// Вернет статус, отличный от optimize.NotTerminated, если метод завершил поиск.
+	tell(xs [][]float64, fs []float64) (optimize.Status, error)
+}
+
+// populationMethod общая часть популяционных методов: одновременное вычисление поколений,
+// отслеживание лучшей точки и сообщение о ней после каждого поколения.
+type populationMethod struct {
+	gen        generational
+	concurrent int
+
+	best        memoryComponent
+	evaluations int
+	generations int
+
+	status optimize.Status
+	err    error
+}
+
+// init подготовка к поиску методом gen, вычисляющим не больше tasks точек одновременно.
+func (p *populationMethod) init(gen generational, tasks int) int {
+	if tasks < 1 {
+		tasks = 1
+	}
+
+	p.gen = gen
+	p.concurrent = tasks
+	p.best = memoryComponent{F: math.Inf(1)}
+	p.evaluations = 0
+	p.generations = 0
+	p.status = optimize.NotTerminated
+	p.err = nil
+
+	return tasks
+}
+
+func (p *populationMethod) Run(operation chan<- optimize.Task, result <-chan optimize.Task, tasks []optimize.Task) {
+	defer close(operation)
+
+	p.gen.start(append([]float64(nil), tasks[0].X...))
+	p.search(operation, result)
+
+	for range result {
+		// дочитываем результаты до закрытия канала.
+	}
+}
+
+// search поколения до завершения оптимизации.
+func (p *populationMethod) search(operation chan<- optimize.Task, result <-chan optimize.Task) {
+	for {
+		xs := p.gen.ask()
+		fs := make([]float64, len(xs))
+
+		if !evaluationBatch(operation, result, p.concurrent, xs, fs) {
+			return
+		}
+
+		status, err := p.told(xs, fs)
+		if status != optimize.NotTerminated || err != nil {
+			p.status = status
+			p.err = err
+
+			methodDone(operation, p.best.X, p.best.F)
+
+			return
+		}
+
+		if !majorIterationAndWait(operation, result, append([]float64(nil), p.best.X...), p.best.F) {
+			return
+		}
+	}
+}
+
+// told учет значений fs поколения xs: проверка NaN, обновление лучшей точки и передача значений методу.
+func (p *populationMethod) told(xs [][]float64, fs []float64) (optimize.Status, error) {
+	p.evaluations += len(xs)
+	p.generations++
+
+	for i, f := range fs {
+		if math.IsNaN(f) {
+			return optimize.Failure, fmt.Errorf("%w at %v", ErrNaN, xs[i])
+		}
+
+		// первая точка становится лучшей, даже если ее значение бесконечно, чтобы лучшая точка была всегда.
+		if f < p.best.F || p.best.X == nil {
+			p.best.F = f
+			p.best.X = append(p.best.X[:0], xs[i]...)
+		}
+	}
+
+	return p.gen.tell(xs, fs)
+}
+
+func (p *populationMethod) Uses(_ optimize.Available) (uses optimize.Available, err error) {
+	return optimize.Available{}, nil
+}
+
+func (p *populationMethod) Status() (optimize.Status, error) {
+	return p.status, p.err
+}
+
+// evaluationBatch вычисление функции в точках xs, не больше concurrent вычислений одновременно.
+// Значения записываются в fs. Вернет false, если оптимизация завершена.
+func evaluationBatch(opr chan<- optimize.Task, res <-chan optimize.Task, concurrent int, xs [][]float64,
+	fs []float64) bool {
+	sent := 0
+
+	send := func() {
+		opr <- optimize.Task{
+			ID:       sent,
+			Op:       optimize.FuncEvaluation,
+			Location: &optimize.Location{X: xs[sent]},
+		}
+		sent++
+	}
+
+	for sent < len(xs) && sent < concurrent {
+		send()
+	}
+
+	for received := 0; received < len(xs); received++ {
+		r, ok := <-res
+		if !ok || r.Op != optimize.FuncEvaluation {
+			return false
+		}
+
+		fs[r.ID] = r.F
+
+		if sent < len(xs) {
+			send()
+		}
+	}
+
+	return true
+}
+
+// randomPoint случайная точка области определения fd.
+func randomPoint(rnd *random.Generator, dim int, fd functions.FuncDomain) []float64 {
+	x := make([]float64, dim)
+	for i := range x {
+		x[i] = rnd.ValueVarInDomain(i, fd)
+	}
+
+	return x
+}
+
+// normalizePoint приведение точки x в область определения fd.
+func normalizePoint(x []float64, fd functions.FuncDomain) []float64 {
+	for i, v := range x {
+		d := fd.VarDomain(i)
+		x[i] = d.Normalize(v)
+	}
+
+	return x
+}
+
+// inDomain лежит ли точка x в области определения fd.
+func inDomain(x []float64, fd functions.FuncDomain) bool {
+	for i, v := range x {
+		d := fd.VarDomain(i)
+		if d.Validate(v) != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bestIndex индекс наименьшего значения.
+func bestIndex(fs []float64) int {
+	best := 0
+
+	for i, f := range fs {
+		if f < fs[best] {
+			best = i
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,350 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultPSOSwarmSize     = 40
+	DefaultPSOInertia       = 0.7298
+	DefaultPSOCognitive     = 1.49618
+	DefaultPSOSocial        = 1.49618
+	DefaultPSOVelocityClamp = 0.2
+
+	// DefaultPSOConstrictionCoefficient коэффициенты c1 и c2 для PSOConstriction (Clerc, Kennedy).
+	DefaultPSOConstrictionCoefficient = 2.05
+)
+
+var (
+	ErrPSOConstriction = errors.New("constriction requires the sum of cognitive and social coefficients above 4")
+	ErrPSOVariant      = errors.New("unknown particle swarm variant")
+	ErrPSOTopology     = errors.New("unknown particle swarm topology")
+)
+
+// PSOVariant формула обновления скорости частицы.
+type PSOVariant int
+
+const (
+	// PSOInertia v = w*v + c1*r1*(pbest - x) + c2*r2*(lbest - x).
+	PSOInertia PSOVariant = iota
+	// PSOConstriction v = chi*(v + c1*r1*(pbest - x) + c2*r2*(lbest - x)), chi вычисляется по c1 + c2.
+	PSOConstriction
+)
+
+// PSOTopology соседи частицы, лучшая точка которых ее притягивает.
+type PSOTopology int
+
+const (
+	// PSOGlobal соседи - весь рой.
+	PSOGlobal PSOTopology = iota
+	// PSORing соседи - частицы слева и справа в кольце.
+	PSORing
+	// PSOVonNeumann соседи - частицы сверху, снизу, слева и справа в торе.
+	PSOVonNeumann
+)
+
+// PSOConfig настройки роя частиц.
+type PSOConfig struct {
+	FD            functions.FuncDomain
+	SwarmSize     int
+	Variant       PSOVariant
+	Inertia       float64 // вес инерции w для PSOInertia.
+	Cognitive     float64 // притяжение к лучшей точке частицы c1.
+	Social        float64 // притяжение к лучшей точке соседей c2.
+	Topology      PSOTopology
+	VelocityClamp float64 // наибольшая скорость в доле ширины области определения, 0 - без ограничения.
+	Seed          uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultPSOConfig() *PSOConfig {
+	return &PSOConfig{
+		FD:            DefaultHSFD,
+		SwarmSize:     DefaultPSOSwarmSize,
+		Variant:       PSOInertia,
+		Inertia:       DefaultPSOInertia,
+		Cognitive:     DefaultPSOCognitive,
+		Social:        DefaultPSOSocial,
+		Topology:      PSOGlobal,
+		VelocityClamp: DefaultPSOVelocityClamp,
+	}
+}
+
+// DefaultPSOConstrictionConfig настройки роя с коэффициентом сжатия.
+func DefaultPSOConstrictionConfig() *PSOConfig {
+	conf := DefaultPSOConfig()
+	conf.Variant = PSOConstriction
+	conf.Cognitive = DefaultPSOConstrictionCoefficient
+	conf.Social = DefaultPSOConstrictionCoefficient
+
+	return conf
+}
+
+func (c *PSOConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.SwarmSize < 1 {
+		return fmt.Errorf("%w: SwarmSize %d", ErrConfigPopulationSize, c.SwarmSize)
+	}
+
+	nonNegative := []struct {
+		name string
+		v    float64
+	}{
+		{name: "Inertia", v: c.Inertia},
+		{name: "Cognitive", v: c.Cognitive},
+		{name: "Social", v: c.Social},
+		{name: "VelocityClamp", v: c.VelocityClamp},
+	}
+
+	for _, v := range nonNegative {
+		if !(v.v >= 0) {
+			return fmt.Errorf("%w: %s %v", ErrConfigNegative, v.name, v.v)
+		}
+	}
+
+	switch c.Variant {
+	case PSOInertia:
+	case PSOConstriction:
+		if c.Cognitive+c.Social <= 4 {
+			return fmt.Errorf("%w: %v", ErrPSOConstriction, c.Cognitive+c.Social)
+		}
+	default:
+		return fmt.Errorf("%w: %d", ErrPSOVariant, c.Variant)
+	}
+
+	if c.Topology < PSOGlobal || c.Topology > PSOVonNeumann {
+		return fmt.Errorf("%w: %d", ErrPSOTopology, c.Topology)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*PSO)(nil)
+
+// PSO метод роя частиц (Particle Swarm Optimization).
+//
+// Частицы, вылетевшие за FD, останавливаются на ее границе. Рой вычисляется одновременно
+// в optimize.Settings.Concurrent задачах.
+type PSO struct {
+	populationMethod
+	conf  *PSOConfig
+	state *psoState
+}
+
+type psoState struct {
+	dim int
+	rnd *random.Generator
+
+	x     [][]float64 // положения частиц.
+	v     [][]float64 // скорости частиц.
+	pbest [][]float64 // лучшие точки частиц.
+	pbF   []float64   // значения в лучших точках частиц.
+
+	neighbors [][]int   // соседи частиц, nil - весь рой.
+	maxV      []float64 // наибольшая скорость по переменным, 0 - без ограничения.
+	chi       float64   // коэффициент сжатия.
+	moved     bool      // вычислены ли начальные положения.
+}
+
+// NewPSO создать экземпляр метода роя частиц. Настройки conf, по умолчанию DefaultPSOConfig, копируются.
+func NewPSO(conf *PSOConfig) (*PSO, error) {
+	if conf == nil {
+		conf = DefaultPSOConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &PSO{conf: &c}, nil
+}
+
+func MustPSO(conf *PSOConfig) *PSO {
+	p, err := NewPSO(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func (p *PSO) Init(dim, tasks int) int {
+	c := p.conf
+	s := &psoState{
+		dim:       dim,
+		rnd:       random.NewGenerator(c.Seed),
+		x:         make([][]float64, c.SwarmSize),
+		v:         make([][]float64, c.SwarmSize),
+		pbest:     make([][]float64, c.SwarmSize),
+		pbF:       make([]float64, c.SwarmSize),
+		neighbors: psoNeighbors(c.Topology, c.SwarmSize),
+		maxV:      make([]float64, dim),
+		chi:       1,
+	}
+
+	for i := range s.maxV {
+		d := c.FD.VarDomain(i)
+		s.maxV[i] = (d.Top - d.Bottom) * c.VelocityClamp
+	}
+
+	if c.Variant == PSOConstriction {
+		phi := c.Cognitive + c.Social
+		s.chi = 2 / math.Abs(2-phi-math.Sqrt(phi*phi-4*phi))
+	}
+
+	p.state = s
+
+	return p.init(p, tasks)
+}
+
+func (p *PSO) start(x []float64) {
+	s := p.state
+
+	for i := range s.x {
+		if i == 0 {
+			s.x[i] = normalizePoint(x, p.conf.FD)
+		} else {
+			s.x[i] = randomPoint(s.rnd, s.dim, p.conf.FD)
+		}
+
+		// начальная скорость - половина пути до случайной точки.
+		target := randomPoint(s.rnd, s.dim, p.conf.FD)
+		s.v[i] = make([]float64, s.dim)
+
+		for j := range s.v[i] {
+			s.v[i][j] = p.clampVelocity(j, (target[j]-s.x[i][j])/2)
+		}
+
+		s.pbest[i] = append([]float64(nil), s.x[i]...)
+		s.pbF[i] = math.Inf(1)
+	}
+
+	s.moved = false
+}
+
+func (p *PSO) ask() [][]float64 {
+	s := p.state
+
+	if !s.moved {
+		s.moved = true
+
+		return s.x
+	}
+
+	global := bestIndex(s.pbF)
+
+	for i := range s.x {
+		lbest := global
+		if s.neighbors != nil {
+			lbest = s.neighborsBest(i)
+		}
+
+		p.move(i, lbest)
+	}
+
+	return s.x
+}
+
+func (p *PSO) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := p.state
+
+	for i, f := range fs {
+		if f < s.pbF[i] {
+			s.pbF[i] = f
+			copy(s.pbest[i], xs[i])
+		}
+	}
+
+	return optimize.NotTerminated, nil
+}
+
+// move обновление скорости и положения частицы i, которую притягивает лучшая точка частицы lbest.
+func (p *PSO) move(i, lbest int) {
+	s := p.state
+	c := p.conf
+	x, v := s.x[i], s.v[i]
+
+	for j := range x {
+		r1, r2 := s.rnd.Float64(), s.rnd.Float64()
+		pull := c.Cognitive*r1*(s.pbest[i][j]-x[j]) + c.Social*r2*(s.pbest[lbest][j]-x[j])
+
+		if c.Variant == PSOConstriction {
+			v[j] = s.chi * (v[j] + pull)
+		} else {
+			v[j] = c.Inertia*v[j] + pull
+		}
+
+		v[j] = p.clampVelocity(j, v[j])
+		x[j] += v[j]
+
+		d := c.FD.VarDomain(j)
+		if d.Validate(x[j]) != nil {
+			x[j] = d.Normalize(x[j])
+			v[j] = 0
+		}
+	}
+}
+
+func (p *PSO) clampVelocity(varIndex int, v float64) float64 {
+	maxV := p.state.maxV[varIndex]
+	if maxV == 0 {
+		return v
+	}
+
+	return math.Max(-maxV, math.Min(maxV, v))
+}
+
+// neighborsBest соседняя частица с лучшей точкой.
+func (s *psoState) neighborsBest(i int) int {
+	best := i
+
+	for _, n := range s.neighbors[i] {
+		if s.pbF[n] < s.pbF[best] {
+			best = n
+		}
+	}
+
+	return best
+}
+
+// psoNeighbors соседи частиц роя размера n, nil - весь рой.
+func psoNeighbors(topology PSOTopology, n int) [][]int {
+	switch topology {
+	case PSORing:
+		neighbors := make([][]int, n)
+		for i := range neighbors {
+			neighbors[i] = []int{(i - 1 + n) % n, (i + 1) % n}
+		}
+
+		return neighbors
+	case PSOVonNeumann:
+		cols := int(math.Ceil(math.Sqrt(float64(n))))
+		rows := (n + cols - 1) / cols
+		neighbors := make([][]int, n)
+
+		for i := range neighbors {
+			r, c := i/cols, i%cols
+			shifts := [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+
+			for _, shift := range shifts {
+				j := ((r+shift[0]+rows)%rows)*cols + (c+shift[1]+cols)%cols
+				if j < n && j != i {
+					neighbors[i] = append(neighbors[i], j)
+				}
+			}
+		}
+
+		return neighbors
+	default:
+		return nil
+	}
+}
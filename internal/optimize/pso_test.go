@@ -0,0 +1,122 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestPSO_Run(t *testing.T) {
+	variants := []struct {
+		name string
+		conf func() *internaloptimize.PSOConfig
+	}{
+		{name: "Inertia", conf: internaloptimize.DefaultPSOConfig},
+		{name: "Constriction", conf: internaloptimize.DefaultPSOConstrictionConfig},
+	}
+	topologies := []struct {
+		name     string
+		topology internaloptimize.PSOTopology
+	}{
+		{name: "Global", topology: internaloptimize.PSOGlobal},
+		{name: "Ring", topology: internaloptimize.PSORing},
+		{name: "VonNeumann", topology: internaloptimize.PSOVonNeumann},
+	}
+	problems := []struct {
+		name    string
+		exp     string
+		minimum float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, minimum: 0},
+		{name: "Levi13", exp: functions.Levi13, minimum: 0},
+	}
+
+	for _, variant := range variants {
+		for _, topology := range topologies {
+			for _, problem := range problems {
+				name := variant.name + "/" + topology.name + "/" + problem.name
+
+				t.Run(name, func(t *testing.T) {
+					asserting := assert.New(t)
+
+					d := functions.VarDomain{Bottom: -10, Top: 10}
+					conf := variant.conf()
+					conf.FD = functions.NewSingleFuncDomain(d)
+					conf.Topology = topology.topology
+					conf.Seed = 3
+
+					prob := functions.MustProblem(problem.exp, nil, nil)
+					settings := &optimize.Settings{
+						Converger:       optimize.NeverTerminate{},
+						FuncEvaluations: 10_000,
+						Concurrent:      4,
+					}
+
+					result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustPSO(conf))
+					asserting.NoError(err)
+					asserting.InDelta(problem.minimum, result.F, 1e-4)
+
+					for _, v := range result.X {
+						asserting.NoError(d.Validate(v))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestPSOConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.PSOConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.PSOConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.PSOConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "EmptySwarm",
+			modify: func(c *internaloptimize.PSOConfig) { c.SwarmSize = 0 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "NegativeInertia",
+			modify: func(c *internaloptimize.PSOConfig) { c.Inertia = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name: "WeakConstriction",
+			modify: func(c *internaloptimize.PSOConfig) {
+				c.Variant = internaloptimize.PSOConstriction
+				c.Cognitive, c.Social = 2, 2
+			},
+			err: internaloptimize.ErrPSOConstriction,
+		},
+		{
+			name:   "UnknownTopology",
+			modify: func(c *internaloptimize.PSOConfig) { c.Topology = 10 },
+			err:    internaloptimize.ErrPSOTopology,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultPSOConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewPSO(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
@@ -0,0 +1,514 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultSALevelLength       = 50
+	DefaultSAInitialAcceptance = 0.8
+	DefaultSAInitialSamples    = 20
+	DefaultSAMinTemperature    = 1e-8
+	DefaultSAReheatTemperature = 0.5
+	DefaultSAGeometricAlpha    = 0.95
+	DefaultSAAdaptiveLambda    = 0.7
+	DefaultSAAdaptiveMinAlpha  = 0.5
+	DefaultSAStepFraction      = 0.1
+)
+
+var (
+	ErrSAOperator     = errors.New("annealing operator is not set")
+	ErrSACooling      = errors.New("cooling factor must be in (0, 1)")
+	ErrSALevelLength  = errors.New("level length must be positive")
+	ErrSASamples      = errors.New("initial temperature samples must be positive")
+	ErrSAFrozen       = errors.New("temperature fell below the minimum")
+	ErrSAAcceptance   = errors.New("initial acceptance must be in (0, 1)")
+	ErrSAStepFraction = errors.New("step fraction must be positive")
+)
+
+// SAFrozen температура опустилась ниже SAConfig.MinTemperature и повторных нагревов не осталось.
+var SAFrozen = optimize.NewStatus("SAFrozen", false, ErrSAFrozen)
+
+// SALevel итоги ходов при одной температуре.
+type SALevel struct {
+	Index       int     // номер уровня от начала или последнего нагрева.
+	Initial     float64 // температура первого уровня.
+	Temperature float64 // температура уровня.
+	Acceptance  float64 // доля принятых ходов.
+	Std         float64 // стандартное отклонение значений в текущей точке после ходов.
+}
+
+// SASchedule закон охлаждения.
+type SASchedule interface {
+	// Next температура следующего уровня.
+	Next(l SALevel) float64
+}
+
+// SANeighbor генератор соседних точек.
+type SANeighbor interface {
+	// Neighbor запись в y случайного соседа точки x, y должен лежать в fd.
+	// ratio - отношение текущей температуры к начальной.
+	Neighbor(rnd *random.Generator, fd functions.FuncDomain, x, y []float64, ratio float64)
+}
+
+// SAGeometric геометрическое охлаждение T = Alpha*T.
+type SAGeometric struct {
+	Alpha float64
+}
+
+func (s SAGeometric) Validate() error {
+	if !(0 < s.Alpha && s.Alpha < 1) {
+		return fmt.Errorf("%w: Alpha %v", ErrSACooling, s.Alpha)
+	}
+
+	return nil
+}
+
+func (s SAGeometric) Next(l SALevel) float64 {
+	return s.Alpha * l.Temperature
+}
+
+// SALogarithmic логарифмическое охлаждение T = T0/ln(e + k).
+type SALogarithmic struct{}
+
+func (SALogarithmic) Next(l SALevel) float64 {
+	return l.Initial / math.Log(math.E+float64(l.Index+1))
+}
+
+// SAAdaptive адаптивное охлаждение (Huang, Romeo, Sangiovanni-Vincentelli) T = T*exp(-Lambda*T/Std):
+// чем больше разброс значений на уровне, тем медленнее охлаждение. Множитель не меньше MinAlpha.
+type SAAdaptive struct {
+	Lambda   float64
+	MinAlpha float64
+}
+
+func (s SAAdaptive) Validate() error {
+	if !(s.Lambda >= 0) {
+		return fmt.Errorf("%w: Lambda %v", ErrConfigNegative, s.Lambda)
+	}
+
+	if !(0 < s.MinAlpha && s.MinAlpha < 1) {
+		return fmt.Errorf("%w: MinAlpha %v", ErrSACooling, s.MinAlpha)
+	}
+
+	return nil
+}
+
+func (s SAAdaptive) Next(l SALevel) float64 {
+	alpha := s.MinAlpha
+	if l.Std > 0 {
+		alpha = math.Max(s.MinAlpha, math.Exp(-s.Lambda*l.Temperature/l.Std))
+	}
+
+	return alpha * l.Temperature
+}
+
+// SAUniformNeighbor сосед со случайным равномерным шагом каждой переменной в пределах Fraction
+// ширины ее области определения. При Annealed шаг уменьшается пропорционально sqrt(ratio).
+type SAUniformNeighbor struct {
+	Fraction float64
+	Annealed bool
+}
+
+func (n SAUniformNeighbor) Validate() error {
+	return validateStepFraction(n.Fraction)
+}
+
+func (n SAUniformNeighbor) Neighbor(rnd *random.Generator, fd functions.FuncDomain, x, y []float64, ratio float64) {
+	neighbor(fd, x, y, stepScale(n.Fraction, n.Annealed, ratio), rnd.UniformStep)
+}
+
+// SAGaussianNeighbor сосед с нормальным шагом каждой переменной, отклонение - Fraction
+// ширины ее области определения. При Annealed шаг уменьшается пропорционально sqrt(ratio).
+type SAGaussianNeighbor struct {
+	Fraction float64
+	Annealed bool
+}
+
+func (n SAGaussianNeighbor) Validate() error {
+	return validateStepFraction(n.Fraction)
+}
+
+func (n SAGaussianNeighbor) Neighbor(rnd *random.Generator, fd functions.FuncDomain, x, y []float64, ratio float64) {
+	neighbor(fd, x, y, stepScale(n.Fraction, n.Annealed, ratio), rnd.NormalStep)
+}
+
+// SACauchyNeighbor сосед с шагом Коши каждой переменной, масштаб - Fraction ширины ее области определения.
+// Тяжелые хвосты дают редкие дальние прыжки. При Annealed шаг уменьшается пропорционально sqrt(ratio).
+type SACauchyNeighbor struct {
+	Fraction float64
+	Annealed bool
+}
+
+func (n SACauchyNeighbor) Validate() error {
+	return validateStepFraction(n.Fraction)
+}
+
+func (n SACauchyNeighbor) Neighbor(rnd *random.Generator, fd functions.FuncDomain, x, y []float64, ratio float64) {
+	neighbor(fd, x, y, stepScale(n.Fraction, n.Annealed, ratio), rnd.CauchyStep)
+}
+
+func validateStepFraction(fraction float64) error {
+	if !(fraction > 0) {
+		return fmt.Errorf("%w: %v", ErrSAStepFraction, fraction)
+	}
+
+	return nil
+}
+
+func stepScale(fraction float64, annealed bool, ratio float64) float64 {
+	if annealed {
+		return fraction * math.Sqrt(math.Min(1, ratio))
+	}
+
+	return fraction
+}
+
+// neighbor y = x + step(scale*ширина переменной), приведенный в область определения fd.
+func neighbor(fd functions.FuncDomain, x, y []float64, scale float64, step func(float64) float64) {
+	for j := range x {
+		d := fd.VarDomain(j)
+		y[j] = d.Normalize(x[j] + step(scale*(d.Top-d.Bottom)))
+	}
+}
+
+// SAConfig настройки имитации отжига.
+type SAConfig struct {
+	FD                 functions.FuncDomain
+	Schedule           SASchedule
+	Neighbor           SANeighbor
+	LevelLength        int     // ходов при одной температуре.
+	InitialTemperature float64 // 0 - оценка по случайным ходам вверх из стартовой точки.
+	InitialAcceptance  float64 // вероятность принять средний ход вверх при оценке начальной температуры.
+	InitialSamples     int     // случайных ходов для оценки начальной температуры.
+	MinTemperature     float64 // ниже - повторный нагрев или завершение SAFrozen, 0 - без ограничения.
+	ReheatStagnation   int     // уровней без улучшения лучшей точки до повторного нагрева, 0 - только по MinTemperature.
+	ReheatTemperature  float64 // температура после нагрева в доле начальной.
+	Reheats            int     // наибольшее количество повторных нагревов.
+	Seed               uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultSAConfig() *SAConfig {
+	return &SAConfig{
+		FD:                DefaultHSFD,
+		Schedule:          SAGeometric{Alpha: DefaultSAGeometricAlpha},
+		Neighbor:          SAGaussianNeighbor{Fraction: DefaultSAStepFraction, Annealed: true},
+		LevelLength:       DefaultSALevelLength,
+		InitialAcceptance: DefaultSAInitialAcceptance,
+		InitialSamples:    DefaultSAInitialSamples,
+		MinTemperature:    DefaultSAMinTemperature,
+		ReheatTemperature: DefaultSAReheatTemperature,
+	}
+}
+
+func (c *SAConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.LevelLength < 1 {
+		return fmt.Errorf("%w: %d", ErrSALevelLength, c.LevelLength)
+	}
+
+	if c.InitialTemperature == 0 {
+		if c.InitialSamples < 1 {
+			return fmt.Errorf("%w: %d", ErrSASamples, c.InitialSamples)
+		}
+
+		if !(0 < c.InitialAcceptance && c.InitialAcceptance < 1) {
+			return fmt.Errorf("%w: %v", ErrSAAcceptance, c.InitialAcceptance)
+		}
+	}
+
+	nonNegative := []struct {
+		name string
+		v    float64
+	}{
+		{name: "InitialTemperature", v: c.InitialTemperature},
+		{name: "MinTemperature", v: c.MinTemperature},
+		{name: "ReheatTemperature", v: c.ReheatTemperature},
+		{name: "ReheatStagnation", v: float64(c.ReheatStagnation)},
+		{name: "Reheats", v: float64(c.Reheats)},
+	}
+
+	for _, v := range nonNegative {
+		if !(v.v >= 0) {
+			return fmt.Errorf("%w: %s %v", ErrConfigNegative, v.name, v.v)
+		}
+	}
+
+	operators := []struct {
+		name string
+		op   interface{}
+	}{
+		{name: "Schedule", op: c.Schedule},
+		{name: "Neighbor", op: c.Neighbor},
+	}
+
+	for _, o := range operators {
+		if o.op == nil {
+			return fmt.Errorf("%w: %s", ErrSAOperator, o.name)
+		}
+
+		if v, ok := o.op.(validator); ok {
+			if err := v.Validate(); err != nil {
+				return fmt.Errorf("%s: %w", o.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*SA)(nil)
+
+// SA метод имитации отжига (Simulated Annealing).
+//
+// Ходы делаются по одному, одновременно вычисляются только случайные ходы для оценки начальной температуры.
+// При остывании ниже MinTemperature или застое ReheatStagnation уровней поиск продолжается от лучшей
+// найденной точки с температурой ReheatTemperature от начальной, пока не исчерпаны Reheats.
+type SA struct {
+	populationMethod
+	conf  *SAConfig
+	state *saState
+}
+
+// saPhase этап имитации отжига.
+type saPhase int
+
+const (
+	saStart    saPhase = iota // вычисление стартовой точки.
+	saSampling                // вычисление случайных ходов для оценки начальной температуры.
+	saMoving                  // ходы.
+)
+
+type saState struct {
+	dim   int
+	rnd   *random.Generator
+	phase saPhase
+
+	x  []float64 // текущая точка.
+	fx float64   // значение в текущей точке.
+	y  []float64 // пробная точка.
+
+	t0           float64 // начальная температура.
+	t            float64 // текущая температура.
+	levelInitial float64 // температура первого уровня после начала или нагрева.
+	level        int     // номер уровня после начала или нагрева.
+
+	moves    int     // ходов на уровне.
+	accepted int     // принятых ходов на уровне.
+	mean     float64 // среднее значений в текущей точке на уровне.
+	m2       float64 // сумма квадратов отклонений значений на уровне.
+
+	levelBestF float64 // лучшее значение в начале уровня.
+	stagnation int     // уровней без улучшения лучшей точки.
+	reheats    int     // выполненных нагревов.
+}
+
+// NewSA создать экземпляр метода имитации отжига. Настройки conf, по умолчанию DefaultSAConfig, копируются.
+func NewSA(conf *SAConfig) (*SA, error) {
+	if conf == nil {
+		conf = DefaultSAConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &SA{conf: &c}, nil
+}
+
+func MustSA(conf *SAConfig) *SA {
+	a, err := NewSA(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+func (a *SA) Init(dim, tasks int) int {
+	a.state = &saState{
+		dim: dim,
+		rnd: random.NewGenerator(a.conf.Seed),
+		y:   make([]float64, dim),
+	}
+
+	return a.init(a, tasks)
+}
+
+func (a *SA) start(x []float64) {
+	s := a.state
+	s.x = normalizePoint(x, a.conf.FD)
+	s.phase = saStart
+}
+
+func (a *SA) ask() [][]float64 {
+	s := a.state
+
+	switch s.phase {
+	case saStart:
+		return [][]float64{s.x}
+	case saSampling:
+		samples := make([][]float64, a.conf.InitialSamples)
+		for i := range samples {
+			samples[i] = make([]float64, s.dim)
+			a.conf.Neighbor.Neighbor(s.rnd, a.conf.FD, s.x, samples[i], 1)
+		}
+
+		return samples
+	default:
+		a.conf.Neighbor.Neighbor(s.rnd, a.conf.FD, s.x, s.y, s.t/s.t0)
+
+		return [][]float64{s.y}
+	}
+}
+
+func (a *SA) tell(xs [][]float64, fs []float64) (optimize.Status, error) {
+	s := a.state
+
+	switch s.phase {
+	case saStart:
+		s.fx = fs[0]
+
+		if a.conf.InitialTemperature > 0 {
+			a.heat(a.conf.InitialTemperature)
+		} else {
+			s.phase = saSampling
+		}
+	case saSampling:
+		a.heat(a.initialTemperature(fs))
+	default:
+		a.move(xs[0], fs[0])
+
+		if s.moves == a.conf.LevelLength {
+			return a.endLevel(), nil
+		}
+	}
+
+	return optimize.NotTerminated, nil
+}
+
+// initialTemperature температура, при которой средний ход вверх из выборки fs принимается
+// с вероятностью InitialAcceptance.
+func (a *SA) initialTemperature(fs []float64) float64 {
+	var uphill, abs float64
+
+	n := 0
+
+	for _, f := range fs {
+		delta := f - a.state.fx
+		abs += math.Abs(delta)
+
+		if delta > 0 {
+			uphill += delta
+			n++
+		}
+	}
+
+	switch {
+	case n > 0:
+		return -uphill / float64(n) / math.Log(a.conf.InitialAcceptance)
+	case abs > 0:
+		return abs / float64(len(fs))
+	default:
+		return 1
+	}
+}
+
+// heat начало ходов с температурой t0.
+func (a *SA) heat(t0 float64) {
+	s := a.state
+	s.t0 = t0
+	s.t = t0
+	s.levelInitial = t0
+	s.level = 0
+	s.phase = saMoving
+	s.levelBestF = a.best.F
+	a.resetLevel()
+}
+
+// move критерий Метрополиса для пробной точки y со значением f.
+func (a *SA) move(y []float64, f float64) {
+	s := a.state
+
+	delta := f - s.fx
+	if delta <= 0 || s.rnd.Float64() < math.Exp(-delta/s.t) {
+		copy(s.x, y)
+		s.fx = f
+		s.accepted++
+	}
+
+	s.moves++
+	d := s.fx - s.mean
+	s.mean += d / float64(s.moves)
+	s.m2 += d * (s.fx - s.mean)
+}
+
+// endLevel охлаждение после уровня, нагрев или завершение.
+func (a *SA) endLevel() optimize.Status {
+	s := a.state
+	c := a.conf
+
+	if a.best.F < s.levelBestF {
+		s.stagnation = 0
+	} else {
+		s.stagnation++
+	}
+
+	s.t = c.Schedule.Next(SALevel{
+		Index:       s.level,
+		Initial:     s.levelInitial,
+		Temperature: s.t,
+		Acceptance:  float64(s.accepted) / float64(s.moves),
+		Std:         math.Sqrt(s.m2 / float64(s.moves)),
+	})
+	s.level++
+	s.levelBestF = a.best.F
+	a.resetLevel()
+
+	frozen := c.MinTemperature > 0 && !(s.t >= c.MinTemperature)
+	stagnant := c.ReheatStagnation > 0 && s.stagnation >= c.ReheatStagnation
+
+	if (frozen || stagnant) && s.reheats < c.Reheats {
+		a.reheat()
+
+		return optimize.NotTerminated
+	}
+
+	if frozen {
+		return SAFrozen
+	}
+
+	return optimize.NotTerminated
+}
+
+// reheat продолжение от лучшей точки с температурой ReheatTemperature от начальной.
+func (a *SA) reheat() {
+	s := a.state
+	s.reheats++
+	s.stagnation = 0
+
+	copy(s.x, a.best.X)
+	s.fx = a.best.F
+
+	s.levelInitial = a.conf.ReheatTemperature * s.t0
+	s.t = s.levelInitial
+	s.level = 0
+}
+
+func (a *SA) resetLevel() {
+	s := a.state
+	s.moves = 0
+	s.accepted = 0
+	s.mean = 0
+	s.m2 = 0
+}
@@ -0,0 +1,165 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestSA_Run(t *testing.T) {
+	schedules := []struct {
+		name     string
+		schedule internaloptimize.SASchedule
+		delta    float64
+	}{
+		{name: "Geometric", schedule: internaloptimize.SAGeometric{Alpha: 0.95}, delta: 1e-2},
+		{name: "Adaptive", schedule: internaloptimize.SAAdaptive{Lambda: 0.7, MinAlpha: 0.5}, delta: 1e-2},
+		{name: "Logarithmic", schedule: internaloptimize.SALogarithmic{}, delta: 0.5},
+	}
+	neighbors := []struct {
+		name     string
+		neighbor internaloptimize.SANeighbor
+	}{
+		{name: "Uniform", neighbor: internaloptimize.SAUniformNeighbor{Fraction: 0.1, Annealed: true}},
+		{name: "Gaussian", neighbor: internaloptimize.SAGaussianNeighbor{Fraction: 0.1, Annealed: true}},
+		{name: "Cauchy", neighbor: internaloptimize.SACauchyNeighbor{Fraction: 0.05, Annealed: true}},
+	}
+
+	for _, schedule := range schedules {
+		for _, neighbor := range neighbors {
+			t.Run(schedule.name+"/"+neighbor.name, func(t *testing.T) {
+				asserting := assert.New(t)
+
+				d := functions.VarDomain{Bottom: -10, Top: 10}
+				conf := internaloptimize.DefaultSAConfig()
+				conf.FD = functions.NewSingleFuncDomain(d)
+				conf.Schedule = schedule.schedule
+				conf.Neighbor = neighbor.neighbor
+				conf.Reheats = 3
+				conf.Seed = 3
+
+				prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+				settings := &optimize.Settings{
+					Converger:       optimize.NeverTerminate{},
+					FuncEvaluations: 10_000,
+					Concurrent:      4,
+				}
+
+				result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustSA(conf))
+				asserting.NoError(err)
+				asserting.InDelta(0, result.F, schedule.delta)
+
+				for _, v := range result.X {
+					asserting.NoError(d.Validate(v))
+				}
+			})
+		}
+	}
+}
+
+func TestSA_Frozen(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultSAConfig()
+	conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: -10, Top: 10})
+	conf.Schedule = internaloptimize.SAGeometric{Alpha: 0.5}
+	conf.InitialTemperature = 10
+	conf.MinTemperature = 1e-3
+	conf.LevelLength = 10
+	conf.Seed = 1
+
+	prob := functions.MustProblem(functions.Matias, nil, nil)
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: 10_000,
+	}
+
+	result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustSA(conf))
+	asserting.NoError(err)
+	asserting.Equal(internaloptimize.SAFrozen, result.Status)
+
+	// 14 уровней до 10*0.5^14 < 1e-3 и стартовая точка.
+	asserting.Equal(14*conf.LevelLength+1, result.FuncEvaluations)
+
+	conf.Reheats = 2
+	reheated, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustSA(conf))
+	asserting.NoError(err)
+	asserting.Equal(internaloptimize.SAFrozen, reheated.Status)
+	asserting.Greater(reheated.FuncEvaluations, result.FuncEvaluations, "reheating must continue the search")
+	asserting.LessOrEqual(reheated.F, result.F)
+}
+
+func TestSAConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.SAConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.SAConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.SAConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "EmptyLevel",
+			modify: func(c *internaloptimize.SAConfig) { c.LevelLength = 0 },
+			err:    internaloptimize.ErrSALevelLength,
+		},
+		{
+			name:   "NoSamples",
+			modify: func(c *internaloptimize.SAConfig) { c.InitialSamples = 0 },
+			err:    internaloptimize.ErrSASamples,
+		},
+		{
+			name: "SamplesNotNeeded",
+			modify: func(c *internaloptimize.SAConfig) {
+				c.InitialTemperature = 1
+				c.InitialSamples = 0
+			},
+		},
+		{
+			name:   "CertainAcceptance",
+			modify: func(c *internaloptimize.SAConfig) { c.InitialAcceptance = 1 },
+			err:    internaloptimize.ErrSAAcceptance,
+		},
+		{
+			name:   "NegativeTemperature",
+			modify: func(c *internaloptimize.SAConfig) { c.InitialTemperature = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name:   "NilSchedule",
+			modify: func(c *internaloptimize.SAConfig) { c.Schedule = nil },
+			err:    internaloptimize.ErrSAOperator,
+		},
+		{
+			name:   "HeatingSchedule",
+			modify: func(c *internaloptimize.SAConfig) { c.Schedule = internaloptimize.SAGeometric{Alpha: 1.1} },
+			err:    internaloptimize.ErrSACooling,
+		},
+		{
+			name:   "ZeroStep",
+			modify: func(c *internaloptimize.SAConfig) { c.Neighbor = internaloptimize.SACauchyNeighbor{} },
+			err:    internaloptimize.ErrSAStepFraction,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultSAConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewSA(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
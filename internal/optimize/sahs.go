@@ -0,0 +1,237 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultSaHSProbToTakeFromMemory          = 0.98
+	DefaultSaHSProbToApplyPitchAdjustment    = 0.9
+	DefaultSaHSProbToTakeFromMemoryStd       = 0.01
+	DefaultSaHSProbToApplyPitchAdjustmentStd = 0.05
+	DefaultSaHSLearningPeriod                = 100
+)
+
+// SaHSConfig настройки самонастраивающегося гармонического поиска.
+//
+// HSConfig.ProbToTakeFromMemory и HSConfig.ProbToApplyPitchAdjustment задают стартовые средние значения.
+type SaHSConfig struct {
+	HSConfig
+	ProbToTakeFromMemoryStd       float64 // отклонение вероятности взять значение из памяти от среднего.
+	ProbToApplyPitchAdjustmentStd float64 // отклонение вероятности сделать шаг от среднего.
+	LearningPeriod                int     // количество импровизаций между пересчетами средних значений.
+}
+
+func DefaultSaHSConfig() *SaHSConfig {
+	conf := DefaultHSConfig()
+	conf.ProbToTakeFromMemory = DefaultSaHSProbToTakeFromMemory
+	conf.ProbToApplyPitchAdjustment = DefaultSaHSProbToApplyPitchAdjustment
+
+	return &SaHSConfig{
+		HSConfig:                      *conf,
+		ProbToTakeFromMemoryStd:       DefaultSaHSProbToTakeFromMemoryStd,
+		ProbToApplyPitchAdjustmentStd: DefaultSaHSProbToApplyPitchAdjustmentStd,
+		LearningPeriod:                DefaultSaHSLearningPeriod,
+	}
+}
+
+// Validate проверка настроек гармонического поиска и самонастройки.
+func (c *SaHSConfig) Validate() error {
+	if err := c.HSConfig.Validate(); err != nil {
+		return err
+	}
+
+	if !(c.ProbToTakeFromMemoryStd >= 0) {
+		return fmt.Errorf("%w: ProbToTakeFromMemoryStd %v", ErrHSConfigNegative, c.ProbToTakeFromMemoryStd)
+	}
+
+	if !(c.ProbToApplyPitchAdjustmentStd >= 0) {
+		return fmt.Errorf("%w: ProbToApplyPitchAdjustmentStd %v", ErrHSConfigNegative,
+			c.ProbToApplyPitchAdjustmentStd)
+	}
+
+	if c.LearningPeriod <= 0 {
+		return fmt.Errorf("%w: LearningPeriod %d", ErrSaHSLearningPeriod, c.LearningPeriod)
+	}
+
+	return nil
+}
+
+// SaHSParams средние значения вероятностей после очередного периода обучения.
+type SaHSParams struct {
+	Improvisation              int     // номер импровизации, на которой пересчитаны средние.
+	ProbToTakeFromMemory       float64 // среднее значение вероятности взять значение из памяти.
+	ProbToApplyPitchAdjustment float64 // среднее значение вероятности сделать шаг.
+}
+
+var ErrSaHSLearningPeriod = errors.New("learning period must be positive")
+
+var _ optimize.Method = (*SaHS)(nil)
+
+// SaHS самонастраивающийся метод гармонического поиска (Self-adaptive HarmonySearch).
+//
+// Вероятности каждой импровизации берутся из нормального распределения вокруг средних значений.
+// Раз в период обучения средние заменяются средними вероятностей успешных импровизаций,
+// т.е. тех, что попали в память.
+type SaHS struct {
+	HS
+	saConf   *SaHSConfig
+	adaptive *hsAdaptiveTuner
+}
+
+// NewSaHS создать экземпляр самонастраивающегося метода гармонического поиска.
+//
+// Настройки conf, по умолчанию DefaultSaHSConfig, копируются и дополняются opts.
+func NewSaHS(conf *SaHSConfig, opts ...HSOption) (*SaHS, error) {
+	if conf == nil {
+		conf = DefaultSaHSConfig()
+	}
+
+	c := *conf
+	for _, opt := range opts {
+		opt(&c.HSConfig)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &SaHS{saConf: &c}
+	s.setup(&c.HSConfig)
+
+	return s, nil
+}
+
+func MustSaHS(conf *SaHSConfig, opts ...HSOption) *SaHS {
+	s, err := NewSaHS(conf, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+func (s *SaHS) Init(dim, tasks int) int {
+	s.adaptive = newHSAdaptiveTuner(s.saConf)
+	s.tuner = s.adaptive
+
+	return s.HS.Init(dim, tasks)
+}
+
+// Trajectory история средних значений вероятностей за последний запуск.
+//
+// Первый элемент содержит стартовые значения.
+func (s *SaHS) Trajectory() []SaHSParams {
+	if s.adaptive == nil {
+		return nil
+	}
+
+	trajectory := make([]SaHSParams, len(s.adaptive.trajectory))
+	copy(trajectory, s.adaptive.trajectory)
+
+	return trajectory
+}
+
+// hsAdaptiveTuner подбор вероятностей по успешным импровизациям.
+type hsAdaptiveTuner struct {
+	conf *SaHSConfig
+
+	mean SaHSParams
+	last SaHSParams
+
+	improvisations int
+	successes      []SaHSParams
+	trajectory     []SaHSParams
+}
+
+func newHSAdaptiveTuner(conf *SaHSConfig) *hsAdaptiveTuner {
+	mean := SaHSParams{
+		ProbToTakeFromMemory:       conf.ProbToTakeFromMemory,
+		ProbToApplyPitchAdjustment: conf.ProbToApplyPitchAdjustment,
+	}
+
+	return &hsAdaptiveTuner{
+		conf:       conf,
+		mean:       mean,
+		last:       mean,
+		trajectory: []SaHSParams{mean},
+	}
+}
+
+func (t *hsAdaptiveTuner) params(rnd *random.Generator) (probToTakeFromMemory, probToApplyPitchAdjustment float64) {
+	t.last.ProbToTakeFromMemory = normalProb(rnd, t.mean.ProbToTakeFromMemory, t.conf.ProbToTakeFromMemoryStd)
+	t.last.ProbToApplyPitchAdjustment = normalProb(rnd, t.mean.ProbToApplyPitchAdjustment,
+		t.conf.ProbToApplyPitchAdjustmentStd)
+
+	return t.last.ProbToTakeFromMemory, t.last.ProbToApplyPitchAdjustment
+}
+
+func (t *hsAdaptiveTuner) feedback(improved bool) {
+	t.improvisations++
+
+	if improved {
+		t.successes = append(t.successes, t.last)
+	}
+
+	if t.improvisations%t.conf.LearningPeriod != 0 || len(t.successes) == 0 {
+		return
+	}
+
+	hmcr := make([]float64, len(t.successes))
+	par := make([]float64, len(t.successes))
+
+	for i, success := range t.successes {
+		hmcr[i] = success.ProbToTakeFromMemory
+		par[i] = success.ProbToApplyPitchAdjustment
+	}
+
+	t.mean = SaHSParams{
+		Improvisation:              t.improvisations,
+		ProbToTakeFromMemory:       floats.Sum(hmcr) / float64(len(hmcr)),
+		ProbToApplyPitchAdjustment: floats.Sum(par) / float64(len(par)),
+	}
+	t.successes = t.successes[:0]
+	t.trajectory = append(t.trajectory, t.mean)
+}
+
+func (t *hsAdaptiveTuner) save(cp *HSCheckpoint) {
+	cp.Tuner = &HSTunerState{
+		Mean:           t.mean,
+		Last:           t.last,
+		Improvisations: t.improvisations,
+		Successes:      append([]SaHSParams(nil), t.successes...),
+		Trajectory:     append([]SaHSParams(nil), t.trajectory...),
+	}
+}
+
+func (t *hsAdaptiveTuner) load(cp *HSCheckpoint) {
+	if cp.Tuner == nil {
+		return
+	}
+
+	t.mean = cp.Tuner.Mean
+	t.last = cp.Tuner.Last
+	t.improvisations = cp.Tuner.Improvisations
+	t.successes = append([]SaHSParams(nil), cp.Tuner.Successes...)
+	t.trajectory = append([]SaHSParams(nil), cp.Tuner.Trajectory...)
+}
+
+// normalProb вероятность из нормального распределения, ограниченная [0, 1].
+func normalProb(rnd *random.Generator, mean, std float64) float64 {
+	p := mean + rnd.NormFloat64()*std
+
+	if p < 0 {
+		return 0
+	}
+
+	if p > 1 {
+		return 1
+	}
+
+	return p
+}
@@ -0,0 +1,53 @@
+package optimize_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestSaHS_Run(t *testing.T) {
+	conf := internaloptimize.DefaultSaHSConfig()
+	conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{
+		Bottom: -10,
+		Top:    10,
+	})
+	conf.MaxStep = internaloptimize.DefaultMaxStep
+	conf.LearningPeriod = 20
+
+	prob := functions.MustProblem(functions.Matias, nil, nil)
+	settings := &optimize.Settings{
+		Runtime: time.Minute,
+		Converger: &optimize.FunctionConverge{
+			Absolute:   1e-2,
+			Iterations: 150,
+		},
+	}
+
+	asserting := assert.New(t)
+
+	sahs := internaloptimize.MustSaHS(conf, internaloptimize.WithHSSeed(3))
+
+	result, err := optimize.Minimize(prob, []float64{10.0, 10.0}, settings, sahs)
+	asserting.NoError(err)
+	asserting.True(math.Abs(result.F) <= 1e-2)
+
+	trajectory := sahs.Trajectory()
+	asserting.NotEmpty(trajectory)
+	asserting.Equal(conf.ProbToTakeFromMemory, trajectory[0].ProbToTakeFromMemory)
+	asserting.Equal(conf.ProbToApplyPitchAdjustment, trajectory[0].ProbToApplyPitchAdjustment)
+
+	for i, params := range trajectory {
+		asserting.True(0 <= params.ProbToTakeFromMemory && params.ProbToTakeFromMemory <= 1)
+		asserting.True(0 <= params.ProbToApplyPitchAdjustment && params.ProbToApplyPitchAdjustment <= 1)
+
+		if i > 0 {
+			asserting.Greater(params.Improvisation, trajectory[i-1].Improvisation)
+		}
+	}
+}
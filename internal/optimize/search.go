@@ -0,0 +1,365 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const DefaultSearchResolution = 10
+
+var (
+	ErrSearchMode            = errors.New("unknown search mode")
+	ErrSearchResolution      = errors.New("grid resolution must be positive")
+	ErrSearchResolutionCount = errors.New("grid resolution must be set once or for every variable")
+	ErrSearchExhausted       = errors.New("all grid points are evaluated")
+	ErrSearchSobolDimension  = errors.New("sobol sequence dimension is not supported")
+)
+
+// SearchExhausted все точки сетки SearchGrid вычислены.
+var SearchExhausted = optimize.NewStatus("SearchExhausted", false, ErrSearchExhausted)
+
+// SearchMode способ перебора точек FD.
+type SearchMode int
+
+const (
+	// SearchRandom равномерно распределенные случайные точки.
+	SearchRandom SearchMode = iota
+	// SearchGrid полный перебор узлов сетки, по Resolution узлов на переменную, включая границы.
+	SearchGrid
+	// SearchSobol квазислучайная последовательность Соболя (направляющие числа Joe, Kuo),
+	// не больше SobolMaxDimension переменных.
+	SearchSobol
+)
+
+// SobolMaxDimension наибольшая размерность последовательности Соболя, первая переменная и sobolDirections.
+const SobolMaxDimension = 21
+
+// SearchConfig настройки базовых методов перебора.
+type SearchConfig struct {
+	FD         functions.FuncDomain
+	Mode       SearchMode
+	Resolution []int  // узлов сетки по каждой переменной для SearchGrid, одно значение - для всех переменных.
+	Batch      int    // точек в поколении, 0 - optimize.Settings.Concurrent.
+	Seed       uint64 // зерно генератора случайных чисел для SearchRandom, 0 - случайное.
+}
+
+func DefaultSearchConfig() *SearchConfig {
+	return &SearchConfig{
+		FD:         DefaultHSFD,
+		Mode:       SearchRandom,
+		Resolution: []int{DefaultSearchResolution},
+	}
+}
+
+func (c *SearchConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.Mode < SearchRandom || c.Mode > SearchSobol {
+		return fmt.Errorf("%w: %d", ErrSearchMode, c.Mode)
+	}
+
+	if c.Batch < 0 {
+		return fmt.Errorf("%w: Batch %d", ErrConfigNegative, c.Batch)
+	}
+
+	if c.Mode == SearchGrid {
+		if len(c.Resolution) == 0 {
+			return ErrSearchResolutionCount
+		}
+
+		for i, r := range c.Resolution {
+			if r < 1 {
+				return fmt.Errorf("%w: variable %d resolution %d", ErrSearchResolution, i, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*Search)(nil)
+
+// Search базовый метод перебора: случайный поиск, сетка или последовательность Соболя.
+//
+// Первой вычисляется стартовая точка, затем точки перебора поколениями по Batch, которые вычисляются
+// одновременно в optimize.Settings.Concurrent задачах. Trace хранит лучшее значение после каждого вычисления.
+type Search struct {
+	populationMethod
+	conf  *SearchConfig
+	state *searchState
+}
+
+type searchState struct {
+	dim   int
+	rnd   *random.Generator
+	batch int
+
+	x0      []float64 // стартовая точка.
+	started bool      // вычислена ли стартовая точка.
+	index   int       // номер следующей точки перебора.
+	total   int       // узлов сетки, -1 - без ограничения.
+	sobol   *sobolSequence
+	err     error // ошибка, обнаруженная до начала перебора.
+	trace   []float64
+}
+
+// NewSearch создать экземпляр метода перебора. Настройки conf, по умолчанию DefaultSearchConfig, копируются.
+func NewSearch(conf *SearchConfig) (*Search, error) {
+	if conf == nil {
+		conf = DefaultSearchConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	c.Resolution = append([]int(nil), c.Resolution...)
+
+	return &Search{conf: &c}, nil
+}
+
+func MustSearch(conf *SearchConfig) *Search {
+	s, err := NewSearch(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Trace лучшее значение функции после каждого вычисления последнего поиска.
+func (s *Search) Trace() []float64 {
+	if s.state == nil {
+		return nil
+	}
+
+	return append([]float64(nil), s.state.trace...)
+}
+
+func (s *Search) Init(dim, tasks int) int {
+	tasks = s.init(s, tasks)
+
+	st := &searchState{
+		dim:   dim,
+		rnd:   random.NewGenerator(s.conf.Seed),
+		batch: s.conf.Batch,
+		total: -1,
+	}
+
+	if st.batch == 0 {
+		st.batch = tasks
+	}
+
+	switch s.conf.Mode {
+	case SearchGrid:
+		if len(s.conf.Resolution) != 1 && len(s.conf.Resolution) != dim {
+			st.err = fmt.Errorf("%w: %d resolutions for %d variables",
+				ErrSearchResolutionCount, len(s.conf.Resolution), dim)
+
+			break
+		}
+
+		st.total = 1
+		for j := 0; j < dim; j++ {
+			st.total *= s.resolution(j)
+		}
+	case SearchSobol:
+		if dim > SobolMaxDimension {
+			st.err = fmt.Errorf("%w: %d > %d", ErrSearchSobolDimension, dim, SobolMaxDimension)
+		}
+	}
+
+	s.state = st
+
+	return tasks
+}
+
+func (s *Search) start(x []float64) {
+	st := s.state
+	st.x0 = normalizePoint(x, s.conf.FD)
+	st.started = false
+	st.index = 0
+	st.sobol = newSobolSequence(st.dim)
+	st.trace = st.trace[:0]
+}
+
+func (s *Search) ask() [][]float64 {
+	st := s.state
+
+	if !st.started {
+		return [][]float64{st.x0}
+	}
+
+	n := st.batch
+	if st.total >= 0 && st.total-st.index < n {
+		n = st.total - st.index
+	}
+
+	xs := make([][]float64, n)
+	for i := range xs {
+		switch s.conf.Mode {
+		case SearchGrid:
+			xs[i] = s.gridPoint(st.index)
+		case SearchSobol:
+			xs[i] = st.sobol.next(s.conf.FD)
+		default:
+			xs[i] = randomPoint(st.rnd, st.dim, s.conf.FD)
+		}
+
+		st.index++
+	}
+
+	return xs
+}
+
+func (s *Search) tell(_ [][]float64, fs []float64) (optimize.Status, error) {
+	st := s.state
+
+	for _, f := range fs {
+		best := f
+		if n := len(st.trace); n > 0 {
+			best = math.Min(best, st.trace[n-1])
+		}
+
+		st.trace = append(st.trace, best)
+	}
+
+	if st.err != nil {
+		return optimize.Failure, st.err
+	}
+
+	st.started = true
+
+	if st.total >= 0 && st.index >= st.total {
+		return SearchExhausted, nil
+	}
+
+	return optimize.NotTerminated, nil
+}
+
+// resolution узлов сетки по переменной j.
+func (s *Search) resolution(j int) int {
+	if len(s.conf.Resolution) == 1 {
+		return s.conf.Resolution[0]
+	}
+
+	return s.conf.Resolution[j]
+}
+
+// gridPoint узел сетки с номером index, первая переменная меняется быстрее всех.
+// Переменная с одним узлом берется в середине своей области определения.
+func (s *Search) gridPoint(index int) []float64 {
+	x := make([]float64, s.state.dim)
+
+	for j := range x {
+		r := s.resolution(j)
+		d := s.conf.FD.VarDomain(j)
+
+		if r == 1 {
+			x[j] = (d.Bottom + d.Top) / 2 //nolint
+		} else {
+			x[j] = d.Bottom + float64(index%r)*(d.Top-d.Bottom)/float64(r-1)
+		}
+
+		index /= r
+	}
+
+	return x
+}
+
+// sobolSequence последовательность Соболя без нулевой точки. Точки строятся последовательно в коде Грея:
+// следующая отличается от предыдущей на одно направляющее число по каждой переменной.
+type sobolSequence struct {
+	x     []uint32 // текущая точка в двоичной дроби.
+	index int      // номер текущей точки.
+}
+
+func newSobolSequence(dim int) *sobolSequence {
+	return &sobolSequence{x: make([]uint32, dim)}
+}
+
+// next следующая точка последовательности в FD.
+func (q *sobolSequence) next(fd functions.FuncDomain) []float64 {
+	// номер младшего нулевого бита номера текущей точки.
+	c := 0
+	for n := q.index; n&1 == 1; n >>= 1 {
+		c++
+	}
+
+	q.index++
+
+	x := make([]float64, len(q.x))
+
+	for j := range x {
+		q.x[j] ^= sobolDirection(j, c)
+
+		d := fd.VarDomain(j)
+		x[j] = d.Normalize(d.Bottom + float64(q.x[j])/(1<<32)*(d.Top-d.Bottom))
+	}
+
+	return x
+}
+
+// sobolDirection направляющее число v_c переменной j, умноженное на 2^32.
+func sobolDirection(j, c int) uint32 {
+	if j == 0 {
+		return 1 << (31 - uint(c))
+	}
+
+	p := sobolDirections[j-1]
+	s := len(p.m)
+
+	v := make([]uint32, c+1)
+	for k := range v {
+		if k < s {
+			v[k] = p.m[k] << (31 - uint(k))
+
+			continue
+		}
+
+		v[k] = v[k-s] ^ (v[k-s] >> uint(s))
+
+		for i := 1; i < s; i++ {
+			if (p.a>>uint(s-1-i))&1 == 1 {
+				v[k] ^= v[k-i]
+			}
+		}
+	}
+
+	return v[c]
+}
+
+// sobolDirections примитивные многочлены a и начальные числа m переменных со второй (Joe, Kuo, new-joe-kuo-6).
+var sobolDirections = []struct {
+	a uint32
+	m []uint32
+}{
+	{a: 0, m: []uint32{1}},
+	{a: 1, m: []uint32{1, 3}},
+	{a: 1, m: []uint32{1, 3, 1}},
+	{a: 2, m: []uint32{1, 1, 1}},
+	{a: 1, m: []uint32{1, 1, 3, 3}},
+	{a: 4, m: []uint32{1, 3, 5, 13}},
+	{a: 2, m: []uint32{1, 1, 5, 5, 17}},
+	{a: 4, m: []uint32{1, 1, 5, 5, 5}},
+	{a: 7, m: []uint32{1, 1, 7, 11, 19}},
+	{a: 11, m: []uint32{1, 1, 5, 1, 1}},
+	{a: 13, m: []uint32{1, 1, 1, 3, 11}},
+	{a: 14, m: []uint32{1, 3, 5, 5, 31}},
+	{a: 1, m: []uint32{1, 3, 3, 9, 7, 49}},
+	{a: 13, m: []uint32{1, 1, 1, 15, 21, 21}},
+	{a: 16, m: []uint32{1, 3, 1, 13, 27, 49}},
+	{a: 19, m: []uint32{1, 1, 1, 15, 7, 5}},
+	{a: 22, m: []uint32{1, 3, 1, 15, 13, 25}},
+	{a: 25, m: []uint32{1, 1, 5, 5, 19, 61}},
+	{a: 1, m: []uint32{1, 3, 7, 11, 23, 15, 103}},
+	{a: 4, m: []uint32{1, 3, 7, 13, 13, 15, 69}},
+}
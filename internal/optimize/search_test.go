@@ -0,0 +1,200 @@
+package optimize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestSearch_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       internaloptimize.SearchMode
+		resolution []int
+		minimum    float64
+		delta      float64
+		status     optimize.Status
+	}{
+		{name: "Random", mode: internaloptimize.SearchRandom, delta: 0.1, status: optimize.FunctionEvaluationLimit},
+		{name: "Sobol", mode: internaloptimize.SearchSobol, delta: 0.1, status: optimize.FunctionEvaluationLimit},
+		// сетка с шагом 0.1 проходит через минимум (3, 2) и заканчивается раньше лимита вычислений.
+		{
+			name:       "Grid",
+			mode:       internaloptimize.SearchGrid,
+			resolution: []int{101},
+			status:     internaloptimize.SearchExhausted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			d := functions.VarDomain{Bottom: -5, Top: 5}
+			conf := internaloptimize.DefaultSearchConfig()
+			conf.FD = functions.NewSingleFuncDomain(d)
+			conf.Mode = test.mode
+			conf.Resolution = test.resolution
+			conf.Seed = 3
+
+			prob := functions.MustProblem(functions.Himmelblau, nil, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 20_000,
+				Concurrent:      4,
+			}
+			search := internaloptimize.MustSearch(conf)
+
+			result, err := optimize.Minimize(prob, []float64{4, 4}, settings, search)
+			asserting.NoError(err)
+			asserting.Equal(test.status, result.Status)
+			asserting.InDelta(test.minimum, result.F, test.delta+1e-12)
+
+			for _, v := range result.X {
+				asserting.NoError(d.Validate(v))
+			}
+
+			trace := search.Trace()
+			asserting.Equal(result.F, trace[len(trace)-1])
+
+			for i := 1; i < len(trace); i++ {
+				asserting.LessOrEqual(trace[i], trace[i-1])
+			}
+		})
+	}
+}
+
+func TestSearch_Sobol(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultSearchConfig()
+	conf.FD = functions.NewSingleFuncDomain(functions.VarDomain{Bottom: 0, Top: 1})
+	conf.Mode = internaloptimize.SearchSobol
+
+	var xs [][]float64
+
+	prob := optimize.Problem{
+		Func: func(x []float64) float64 {
+			xs = append(xs, append([]float64(nil), x...))
+
+			return x[0]
+		},
+	}
+	settings := &optimize.Settings{Converger: optimize.NeverTerminate{}, FuncEvaluations: 6}
+
+	_, err := optimize.Minimize(prob, []float64{0, 0, 0}, settings, internaloptimize.MustSearch(conf))
+	asserting.NoError(err)
+
+	// первая точка стартовая, затем последовательность Соболя без нулевой точки.
+	asserting.Equal([][]float64{
+		{0, 0, 0},
+		{0.5, 0.5, 0.5},
+		{0.75, 0.25, 0.25},
+		{0.25, 0.75, 0.75},
+		{0.375, 0.375, 0.625},
+		{0.875, 0.875, 0.125},
+	}, xs)
+}
+
+func TestSearch_Dimension(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       internaloptimize.SearchMode
+		resolution []int
+		dim        int
+		err        error
+	}{
+		{
+			name: "SobolTooManyVariables",
+			mode: internaloptimize.SearchSobol,
+			dim:  internaloptimize.SobolMaxDimension + 1,
+			err:  internaloptimize.ErrSearchSobolDimension,
+		},
+		{
+			name:       "GridResolutionCount",
+			mode:       internaloptimize.SearchGrid,
+			resolution: []int{3, 3},
+			dim:        3,
+			err:        internaloptimize.ErrSearchResolutionCount,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultSearchConfig()
+			conf.Mode = test.mode
+			conf.Resolution = test.resolution
+
+			prob := optimize.Problem{Func: func(x []float64) float64 { return 0 }}
+			settings := &optimize.Settings{Converger: optimize.NeverTerminate{}, FuncEvaluations: 10}
+
+			_, err := optimize.Minimize(prob, make([]float64, test.dim), settings, internaloptimize.MustSearch(conf))
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestSearchConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.SearchConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.SearchConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.SearchConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "UnknownMode",
+			modify: func(c *internaloptimize.SearchConfig) { c.Mode = 10 },
+			err:    internaloptimize.ErrSearchMode,
+		},
+		{
+			name:   "NegativeBatch",
+			modify: func(c *internaloptimize.SearchConfig) { c.Batch = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name: "GridWithoutResolution",
+			modify: func(c *internaloptimize.SearchConfig) {
+				c.Mode = internaloptimize.SearchGrid
+				c.Resolution = nil
+			},
+			err: internaloptimize.ErrSearchResolutionCount,
+		},
+		{
+			name: "GridZeroResolution",
+			modify: func(c *internaloptimize.SearchConfig) {
+				c.Mode = internaloptimize.SearchGrid
+				c.Resolution = []int{5, 0}
+			},
+			err: internaloptimize.ErrSearchResolution,
+		},
+		{
+			name:   "ResolutionNotNeeded",
+			modify: func(c *internaloptimize.SearchConfig) { c.Resolution = nil },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultSearchConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewSearch(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
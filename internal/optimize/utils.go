@@ -20,6 +20,52 @@ func majorIteration(opr chan<- optimize.Task, x []float64, f float64) {
 	}
 }
 
+func methodDone(opr chan<- optimize.Task, x []float64, f float64) {
+	opr <- optimize.Task{
+		Op:       optimize.MethodDone,
+		Location: &optimize.Location{X: x, F: f},
+	}
+}
+
+// evaluation вычисление функции в точке x.
+// Вернет false, если оптимизация завершена и значение не получено.
+func evaluation(opr chan<- optimize.Task, res <-chan optimize.Task, x []float64) (float64, bool) {
+	funcEvaluation(opr, x)
+
+	r, ok := <-res
+	if !ok || r.Op != optimize.FuncEvaluation {
+		return 0, false
+	}
+
+	return r.F, true
+}
+
+// gradEvaluation вычисление градиента в точке x.
+// Вернет false, если оптимизация завершена и градиент не получен.
+func gradEvaluation(opr chan<- optimize.Task, res <-chan optimize.Task, x []float64) ([]float64, bool) {
+	opr <- optimize.Task{
+		Op:       optimize.GradEvaluation,
+		Location: &optimize.Location{X: x},
+	}
+
+	r, ok := <-res
+	if !ok || r.Op != optimize.GradEvaluation {
+		return nil, false
+	}
+
+	return r.Gradient, true
+}
+
+// majorIterationAndWait отправка лучшего значения и ожидание его возврата.
+// Вернет false, если оптимизация завершена.
+func majorIterationAndWait(opr chan<- optimize.Task, res <-chan optimize.Task, x []float64, f float64) bool {
+	majorIteration(opr, x, f)
+
+	r, ok := <-res
+
+	return ok && r.Op == optimize.MajorIteration
+}
+
 type FunctionConverge struct {
 	Absolute float64
 
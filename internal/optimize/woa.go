@@ -0,0 +1,200 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"github.com/EmptyShadow/eltech.optimize/internal/random"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	DefaultWOAPopulationSize = 25
+	DefaultWOAGenerations    = 300
+	DefaultWOASpiral         = 1
+	DefaultWOASpiralRate     = 0.5
+)
+
+var ErrWOAGenerations = errors.New("generations must be positive")
+
+// WOAConfig настройки стаи китов.
+type WOAConfig struct {
+	FD             functions.FuncDomain
+	PopulationSize int
+	Generations    int     // поколений, за которые коэффициент a убывает от 2 до 0, дальше a = 0.
+	Spiral         float64 // постоянная b логарифмической спирали e^(b*l).
+	SpiralRate     float64 // вероятность движения по спирали вместо окружения.
+	Seed           uint64  // зерно генератора случайных чисел, 0 - случайное.
+}
+
+func DefaultWOAConfig() *WOAConfig {
+	return &WOAConfig{
+		FD:             DefaultHSFD,
+		PopulationSize: DefaultWOAPopulationSize,
+		Generations:    DefaultWOAGenerations,
+		Spiral:         DefaultWOASpiral,
+		SpiralRate:     DefaultWOASpiralRate,
+	}
+}
+
+func (c *WOAConfig) Validate() error {
+	if c.FD == nil {
+		return ErrConfigFD
+	}
+
+	if c.PopulationSize < 2 { //nolint
+		return fmt.Errorf("%w: PopulationSize %d", ErrConfigPopulationSize, c.PopulationSize)
+	}
+
+	if c.Generations < 1 {
+		return fmt.Errorf("%w: %d", ErrWOAGenerations, c.Generations)
+	}
+
+	if !(c.Spiral >= 0) {
+		return fmt.Errorf("%w: Spiral %v", ErrConfigNegative, c.Spiral)
+	}
+
+	if !(0 <= c.SpiralRate && c.SpiralRate <= 1) {
+		return fmt.Errorf("%w: SpiralRate %v", ErrConfigProbability, c.SpiralRate)
+	}
+
+	return nil
+}
+
+var _ optimize.Method = (*WOA)(nil)
+
+// WOA метод китов (Whale Optimization Algorithm, Mirjalili, Lewis).
+//
+// Кит либо окружает лучшую найденную точку, либо при |A| >= 1 плывет относительно случайного кита,
+// либо приближается к лучшей точке по спирали пузырьковой сети. Стая вычисляется одновременно
+// в optimize.Settings.Concurrent задачах.
+type WOA struct {
+	populationMethod
+	conf  *WOAConfig
+	state *woaState
+}
+
+type woaState struct {
+	dim        int
+	rnd        *random.Generator
+	generation int
+
+	whales [][]float64 // положения китов.
+	prev   [][]float64 // положения китов предыдущего поколения.
+}
+
+// NewWOA создать экземпляр метода китов. Настройки conf, по умолчанию DefaultWOAConfig, копируются.
+func NewWOA(conf *WOAConfig) (*WOA, error) {
+	if conf == nil {
+		conf = DefaultWOAConfig()
+	}
+
+	c := *conf
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &WOA{conf: &c}, nil
+}
+
+func MustWOA(conf *WOAConfig) *WOA {
+	w, err := NewWOA(conf)
+	if err != nil {
+		panic(err)
+	}
+
+	return w
+}
+
+func (w *WOA) Init(dim, tasks int) int {
+	s := &woaState{
+		dim:    dim,
+		rnd:    random.NewGenerator(w.conf.Seed),
+		whales: make([][]float64, w.conf.PopulationSize),
+		prev:   make([][]float64, w.conf.PopulationSize),
+	}
+
+	for i := range s.prev {
+		s.prev[i] = make([]float64, dim)
+	}
+
+	w.state = s
+
+	return w.init(w, tasks)
+}
+
+func (w *WOA) start(x []float64) {
+	s := w.state
+
+	for i := range s.whales {
+		if i == 0 {
+			s.whales[i] = normalizePoint(x, w.conf.FD)
+		} else {
+			s.whales[i] = randomPoint(s.rnd, s.dim, w.conf.FD)
+		}
+	}
+
+	s.generation = 0
+}
+
+func (w *WOA) ask() [][]float64 {
+	s := w.state
+
+	if s.generation == 0 {
+		return s.whales
+	}
+
+	for i, x := range s.whales {
+		copy(s.prev[i], x)
+	}
+
+	a := convergenceFactor(s.generation, w.conf.Generations)
+
+	for i := range s.whales {
+		w.swim(i, a)
+	}
+
+	return s.whales
+}
+
+func (w *WOA) tell(_ [][]float64, _ []float64) (optimize.Status, error) {
+	w.state.generation++
+
+	return optimize.NotTerminated, nil
+}
+
+// swim новое положение кита i при коэффициенте a.
+func (w *WOA) swim(i int, a float64) {
+	s := w.state
+	x := s.whales[i]
+	best := w.best.X
+
+	A := a * s.rnd.FloatInRange(-1, 1) //nolint
+	C := 2 * s.rnd.Float64()           //nolint
+
+	if s.rnd.Float64() < w.conf.SpiralRate {
+		l := s.rnd.FloatInRange(-1, 1)
+		spiral := math.Exp(w.conf.Spiral*l) * math.Cos(2*math.Pi*l)
+
+		for j := range x {
+			x[j] = math.Abs(best[j]-s.prev[i][j])*spiral + best[j]
+		}
+	} else {
+		// при |A| >= 1 кит ищет добычу около случайного кита, иначе окружает лучшую точку.
+		target := best
+		if math.Abs(A) >= 1 {
+			target = s.prev[s.rnd.Intn(len(s.prev))]
+		}
+
+		for j := range x {
+			x[j] = target[j] - A*math.Abs(C*target[j]-s.prev[i][j])
+		}
+	}
+
+	for j := range x {
+		d := w.conf.FD.VarDomain(j)
+		x[j] = d.Normalize(x[j])
+	}
+}
@@ -0,0 +1,119 @@
+package optimize_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	internaloptimize "github.com/EmptyShadow/eltech.optimize/internal/optimize"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestWOA_Run(t *testing.T) {
+	problems := []struct {
+		name    string
+		exp     string
+		minimum float64
+		delta   float64
+	}{
+		{name: "Himmelblau", exp: functions.Himmelblau, minimum: 0, delta: 1e-2},
+		// стая быстро сходится к лучшей точке и может остаться в локальном минимуме около 0.11.
+		{name: "Levi13", exp: functions.Levi13, minimum: 0, delta: 0.2},
+		{name: "Matias", exp: functions.Matias, minimum: 0, delta: 1e-2},
+	}
+
+	for _, problem := range problems {
+		t.Run(problem.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			d := functions.VarDomain{Bottom: -10, Top: 10}
+			conf := internaloptimize.DefaultWOAConfig()
+			conf.FD = functions.NewSingleFuncDomain(d)
+			conf.Seed = 3
+
+			prob := functions.MustProblem(problem.exp, nil, nil)
+			settings := &optimize.Settings{
+				Converger:       optimize.NeverTerminate{},
+				FuncEvaluations: 10_000,
+				Concurrent:      4,
+			}
+
+			result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustWOA(conf))
+			asserting.NoError(err)
+			asserting.InDelta(problem.minimum, result.F, problem.delta)
+
+			for _, v := range result.X {
+				asserting.NoError(d.Validate(v))
+			}
+		})
+	}
+}
+
+func TestWOA_RunInfinite(t *testing.T) {
+	asserting := assert.New(t)
+
+	conf := internaloptimize.DefaultWOAConfig()
+	conf.Seed = 3
+
+	prob := optimize.Problem{Func: func(x []float64) float64 { return math.Inf(1) }}
+	settings := &optimize.Settings{
+		Converger:       optimize.NeverTerminate{},
+		FuncEvaluations: 10 * conf.PopulationSize,
+	}
+
+	result, err := optimize.Minimize(prob, []float64{9, 9}, settings, internaloptimize.MustWOA(conf))
+	asserting.NoError(err)
+	asserting.Equal(optimize.FunctionEvaluationLimit, result.Status)
+	asserting.True(math.IsInf(result.F, 1))
+}
+
+func TestWOAConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *internaloptimize.WOAConfig)
+		err    error
+	}{
+		{name: "Default", modify: func(c *internaloptimize.WOAConfig) {}},
+		{name: "NilFD", modify: func(c *internaloptimize.WOAConfig) { c.FD = nil }, err: internaloptimize.ErrConfigFD},
+		{
+			name:   "SingleWhale",
+			modify: func(c *internaloptimize.WOAConfig) { c.PopulationSize = 1 },
+			err:    internaloptimize.ErrConfigPopulationSize,
+		},
+		{
+			name:   "ZeroGenerations",
+			modify: func(c *internaloptimize.WOAConfig) { c.Generations = 0 },
+			err:    internaloptimize.ErrWOAGenerations,
+		},
+		{
+			name:   "NegativeSpiral",
+			modify: func(c *internaloptimize.WOAConfig) { c.Spiral = -1 },
+			err:    internaloptimize.ErrConfigNegative,
+		},
+		{
+			name:   "SpiralRateAboveOne",
+			modify: func(c *internaloptimize.WOAConfig) { c.SpiralRate = 2 },
+			err:    internaloptimize.ErrConfigProbability,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			asserting := assert.New(t)
+
+			conf := internaloptimize.DefaultWOAConfig()
+			test.modify(conf)
+
+			_, err := internaloptimize.NewWOA(conf)
+			if test.err == nil {
+				asserting.NoError(err)
+
+				return
+			}
+
+			asserting.True(errors.Is(err, test.err), "unexpected error %v", err)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package random
+
+import (
+	"math"
+	mathrand "math/rand"
+
+	"github.com/EmptyShadow/eltech.optimize/internal/functions"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Generator генератор случайных значений, состояние которого можно сохранить и восстановить.
+type Generator struct {
+	*rand.Rand
+	src *rand.PCGSource
+}
+
+// NewGenerator генератор с зерном seed, при seed == 0 зерно выбирается случайно.
+func NewGenerator(seed uint64) *Generator {
+	if seed == 0 {
+		seed = mathrand.Uint64()
+	}
+
+	src := &rand.PCGSource{}
+	src.Seed(seed)
+
+	return &Generator{
+		Rand: rand.New(src),
+		src:  src,
+	}
+}
+
+// MarshalBinary состояние генератора.
+func (g *Generator) MarshalBinary() ([]byte, error) {
+	return g.src.MarshalBinary()
+}
+
+// UnmarshalBinary восстановление состояния генератора.
+func (g *Generator) UnmarshalBinary(data []byte) error {
+	return g.src.UnmarshalBinary(data)
+}
+
+// FloatInRange генерация значения в пределах [b, t].
+func (g *Generator) FloatInRange(b, t float64) float64 {
+	return b + g.Float64()*(t-b)
+}
+
+// UniformStep шаг, равномерно распределенный в [-width, width].
+func (g *Generator) UniformStep(width float64) float64 {
+	return g.FloatInRange(-width, width)
+}
+
+// NormalStep шаг из нормального распределения с нулевым средним и отклонением std.
+func (g *Generator) NormalStep(std float64) float64 {
+	return g.NormFloat64() * std
+}
+
+// CauchyStep шаг из распределения Коши с нулевым центром и масштабом scale.
+func (g *Generator) CauchyStep(scale float64) float64 {
+	return scale * math.Tan(math.Pi*(g.Float64()-0.5)) //nolint
+}
+
+// LevyStep шаг полета Леви с показателем beta из (0, 2] по алгоритму Мантеньи.
+func (g *Generator) LevyStep(beta float64) float64 {
+	num := math.Gamma(1+beta) * math.Sin(math.Pi*beta/2)           //nolint
+	den := math.Gamma((1+beta)/2) * beta * math.Pow(2, (beta-1)/2) //nolint
+	sigma := math.Pow(num/den, 1/beta)
+
+	return g.NormFloat64() * sigma / math.Pow(math.Abs(g.NormFloat64()), 1/beta)
+}
+
+// ValueVar генерация значения из области определения.
+func (g *Generator) ValueVar(d functions.VarDomain) float64 {
+	return g.FloatInRange(d.Bottom, d.Top)
+}
+
+// ValueVarInDomain генерация значения из области определения для определенной переменной.
+func (g *Generator) ValueVarInDomain(varIndex int, fd functions.FuncDomain) float64 {
+	return g.ValueVar(fd.VarDomain(varIndex))
+}
+
+// MatrixInDomain генерация матрицы в пределах определеня функции.
+func (g *Generator) MatrixInDomain(r, c int, fd functions.FuncDomain) *mat.Dense {
+	memory := make([]float64, r*c)
+
+	for i := 0; i < r*c; i++ {
+		varIndex := i % c
+		memory[i] = g.ValueVarInDomain(varIndex, fd)
+	}
+
+	return mat.NewDense(r, c, memory)
+}